@@ -2,12 +2,19 @@ package cmd
 
 import (
 	"github.com/ameykpatil/github-data-analyzer/db"
+	"github.com/ameykpatil/github-data-analyzer/domain/persona"
 	"github.com/ameykpatil/github-data-analyzer/domain/repo"
 	"github.com/ameykpatil/github-data-analyzer/domain/user"
 	"github.com/ameykpatil/github-data-analyzer/service"
 	"github.com/spf13/cobra"
 )
 
+// sort fields of the top user list & of each top repo list of all command
+var (
+	allUserSortFields = []string{"PullRequestEvent", "Commits"}
+	allRepoSortFields = []string{"Commits", "WatchEvent"}
+)
+
 // NewAllCmd command to get top users & repos
 func NewAllCmd() *cobra.Command {
 	allCmd := &cobra.Command{
@@ -17,7 +24,9 @@ func NewAllCmd() *cobra.Command {
 	}
 
 	allCmd.Flags().StringP("path", "p", "", "path of the directory where the data files are")
-	allCmd.Flags().Uint32P("limit", "l", 10, "number of users to return")
+	allCmd.Flags().Uint32P("limit", "l", 10, "number of users to return, 0 returns all")
+	allCmd.Flags().Uint32P("offset", "o", 0, "number of top users to skip")
+	addRankFlags(allCmd)
 
 	return allCmd
 }
@@ -32,6 +41,14 @@ func getTopUsersAndRepos(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	offset, err := cmd.Flags().GetUint32("offset")
+	if err != nil {
+		return err
+	}
+	rankOptions, err := getRankOptions(cmd)
+	if err != nil {
+		return err
+	}
 
 	// initialise dependencies
 	dataStore, err := db.NewDataStore(path)
@@ -40,32 +57,30 @@ func getTopUsersAndRepos(cmd *cobra.Command, args []string) error {
 	}
 	eventHandler := service.NewEventHandler(dataStore)
 	userAnalyzer := user.NewAnalyzer(*eventHandler)
-	repoAnalyzer := repo.NewAnalyzer(*eventHandler)
-
-	// get top users by passing custom sort function
-	users := userAnalyzer.GetTopUsers(limit, func(ui, uj user.User) bool {
-		if ui.EventTypeCount["PullRequestEvent"] == uj.EventTypeCount["PullRequestEvent"] {
-			return ui.CommitCount > uj.CommitCount
-		} else if ui.EventTypeCount["PullRequestEvent"] > uj.EventTypeCount["PullRequestEvent"] {
-			return true
-		}
-		return false
-	})
+	repoAnalyzer := repo.NewAnalyzer(*eventHandler, repo.Options{})
 
-	// get top repos by commits by passing custom sort function
-	reposByCommits := repoAnalyzer.GetTopRepos(limit, func(ri, rj repo.Repo) bool {
-		return ri.CommitCount > rj.CommitCount
-	})
-
-	// get top repos by watch events by passing custom sort function
-	reposByWatchEvents := repoAnalyzer.GetTopRepos(limit, func(ri, rj repo.Repo) bool {
-		return ri.EventTypeCount["WatchEvent"] > rj.EventTypeCount["WatchEvent"]
-	})
+	// get top users by PRs & then commits
+	userSortFn, err := getSortFunction(allUserSortFields)
+	if err != nil {
+		return err
+	}
+	users := userAnalyzer.GetRankedUsers(limit, offset, userSortFn, rankOptions)
+	if err := printUsers(users, limit, allUserSortFields, nil, persona.DefaultThresholds); err != nil {
+		return err
+	}
 
-	// print the results in readable format
-	printUsers(users, limit, []string{"PullRequestEvent", "Commits"})
-	printRepos(reposByCommits, limit, []string{"Commits"})
-	printRepos(reposByWatchEvents, limit, []string{"WatchEvent"})
+	// get top repos by commits & by watch events
+	for _, sortField := range allRepoSortFields {
+		repoSortFn, err := getRepoSortFunction([]string{sortField})
+		if err != nil {
+			return err
+		}
+		repos := repoAnalyzer.GetRankedRepos(limit, offset, repoSortFn, rankOptions)
+		if err := printRepos(repos, limit, []string{sortField}, nil); err != nil {
+			return err
+		}
+	}
+	printSharedCommits(eventHandler)
 
 	return nil
 }
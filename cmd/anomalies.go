@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ameykpatil/github-data-analyzer/db"
+	"github.com/ameykpatil/github-data-analyzer/domain/anomaly"
+	"github.com/ameykpatil/github-data-analyzer/domain/repo"
+	"github.com/ameykpatil/github-data-analyzer/domain/user"
+	"github.com/ameykpatil/github-data-analyzer/service"
+	"github.com/spf13/cobra"
+)
+
+// NewAnomaliesCmd command to get the most unusual users & repos
+func NewAnomaliesCmd() *cobra.Command {
+	anomaliesCmd := &cobra.Command{
+		Use:   "anomalies",
+		Short: "Get the most unusual users & repos",
+		RunE:  getAnomalies,
+	}
+
+	anomaliesCmd.Flags().StringP("path", "p", "", "path of the directory where the data files are")
+	anomaliesCmd.Flags().String("of", "all", "entities to detect the anomalies of, users, repos or all")
+	anomaliesCmd.Flags().Uint32P("limit", "l", 10, "number of users & repos to return, 0 returns all")
+	anomaliesCmd.Flags().Uint32P("offset", "o", 0, "number of top users & repos to skip")
+	anomaliesCmd.Flags().Int("min-events", 5, "minimum number of events for a user or repo to be scored")
+
+	return anomaliesCmd
+}
+
+func getAnomalies(cmd *cobra.Command, args []string) error {
+	// get & verify the flags
+	path, err := cmd.Flags().GetString("path")
+	if err != nil {
+		return err
+	}
+	of, err := cmd.Flags().GetString("of")
+	if err != nil {
+		return err
+	}
+	if of != "users" && of != "repos" && of != "all" {
+		return errors.New("invalid entities " + of)
+	}
+	limit, err := cmd.Flags().GetUint32("limit")
+	if err != nil {
+		return err
+	}
+	offset, err := cmd.Flags().GetUint32("offset")
+	if err != nil {
+		return err
+	}
+	minEvents, err := cmd.Flags().GetInt("min-events")
+	if err != nil {
+		return err
+	}
+
+	// initialise dependencies
+	dataStore, err := db.NewDataStore(path)
+	if err != nil {
+		return err
+	}
+	eventHandler := service.NewEventHandler(dataStore)
+	userAnalyzer := user.NewAnalyzer(*eventHandler)
+	repoAnalyzer := repo.NewAnalyzer(*eventHandler, repo.Options{})
+	anomalyAnalyzer := anomaly.NewAnalyzer(userAnalyzer, repoAnalyzer)
+
+	// get the anomalies & print the result in readable format
+	if of != "repos" {
+		printAnomalies(anomalyAnalyzer.GetUserAnomalies(minEvents, limit, offset), limit, "Users")
+	}
+	if of != "users" {
+		printAnomalies(anomalyAnalyzer.GetRepoAnomalies(minEvents, limit, offset), limit, "Repos")
+	}
+
+	return nil
+}
+
+// printAnomalies print anomalies in readable format
+func printAnomalies(anomalies []anomaly.Anomaly, limit uint32, title string) {
+	var str strings.Builder
+	for _, a := range anomalies {
+		fmt.Fprintf(&str, "Score:%.2f Metric:%s Value:%.4g Median:%.4g ID:%s Name:%s \n", a.Score, a.Metric, a.Value, a.Median, a.ID, a.Name)
+	}
+	fmt.Printf("Top %d Unusual %s \n --- \n%s --- \n", limit, title, str.String())
+}
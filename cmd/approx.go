@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ameykpatil/github-data-analyzer/domain/approx"
+	"github.com/ameykpatil/github-data-analyzer/service"
+	"github.com/spf13/cobra"
+)
+
+// addApproximateFlags adds the flags of the approximate mode to the command
+func addApproximateFlags(cmd *cobra.Command) {
+	cmd.Flags().Bool("approximate", false, "estimate the top list & distinct counts with sketches in bounded memory, ranked by a single sort field Commits or an event type")
+	cmd.Flags().StringSlice("sketch-in", []string{}, "files of the sketches to merge in approximate mode")
+	cmd.Flags().String("sketch-out", "", "file to write the merged sketches to in approximate mode")
+}
+
+// getSummary creates the approximate summary of the data files in path (if any) merged with the sketch-in files
+// the summary is written to the sketch-out file if given
+func getSummary(cmd *cobra.Command, path string) (*approx.Summary, error) {
+	sketchIn, err := cmd.Flags().GetStringSlice("sketch-in")
+	if err != nil {
+		return nil, err
+	}
+	sketchOut, err := cmd.Flags().GetString("sketch-out")
+	if err != nil {
+		return nil, err
+	}
+	if path == "" && len(sketchIn) == 0 {
+		return nil, errors.New("path or sketch-in files are required in approximate mode")
+	}
+
+	summary, err := approx.NewSummary(approx.Options{})
+	if err != nil {
+		return nil, err
+	}
+	if path != "" {
+		if summary, err = approx.SummarizeFiles(path, approx.Options{}); err != nil {
+			return nil, err
+		}
+	}
+	for _, file := range sketchIn {
+		other, err := readSummary(file)
+		if err != nil {
+			return nil, err
+		}
+		if err := summary.Merge(other); err != nil {
+			return nil, fmt.Errorf("%s: %v", file, err)
+		}
+	}
+
+	if sketchOut != "" {
+		out, err := os.Create(sketchOut)
+		if err != nil {
+			return nil, err
+		}
+		defer out.Close()
+		if err := summary.Write(out); err != nil {
+			return nil, err
+		}
+	}
+	return summary, nil
+}
+
+// readSummary reads the approximate summary from the file
+func readSummary(file string) (*approx.Summary, error) {
+	in, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer in.Close()
+	return approx.Read(in)
+}
+
+// getApproximateMetric returns the metric of the sort fields supported in approximate mode i.e. Commits or an event type
+// the default sort fields rank by the first one, the scoped, distinct, score & other derived fields are rejected
+func getApproximateMetric(cmd *cobra.Command, sortFields []string, metricNames []string) (string, error) {
+	if len(sortFields) == 0 {
+		return approx.Commits, nil
+	}
+	if len(sortFields) > 1 && cmd.Flags().Changed("sort") {
+		return "", errors.New("approximate mode supports a single sort field")
+	}
+	key := parseSortKey(sortFields[0])
+	if key.ascending {
+		return "", errors.New("approximate mode supports only descending sort")
+	}
+	if key.field == approx.Commits || service.IsEventType(key.field) {
+		return key.field, nil
+	}
+	if isScoreField(key.field) || strings.Contains(key.field, ":") || containsString(metricNames, key.field) {
+		return "", fmt.Errorf("approximate mode supports only Commits or an event type, not %s", key.field)
+	}
+	return "", unknownFieldError(key.field, append([]string{approx.Commits}, service.EventTypes...))
+}
+
+// containsString checks if the value is one of the values
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// printEstimates print the estimated top list & distinct count in readable format
+func printEstimates(of string, estimates []approx.Estimate, limit uint32, metric string, d approx.Distinct) {
+	var str strings.Builder
+	for _, estimate := range estimates {
+		fmt.Fprintf(&str, "%s:~%d Error:%d ID:%s Name:%s \n", metric, estimate.Count, estimate.Error, estimate.ID, estimate.Name)
+	}
+	confidence := 0.0
+	if len(estimates) > 0 {
+		confidence = estimates[0].Confidence
+	}
+	fmt.Printf("Approximate Top %d %s by [%s] (true values are lower by at most the error with %.1f%% confidence) \n --- \n%s --- \n",
+		limit, of, metric, 100*confidence, str.String())
+	fmt.Printf("Distinct %s: ~%.0f (±%.2f%%) \n", strings.ToLower(of), d.Count, 100*d.RelativeError)
+}
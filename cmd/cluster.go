@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ameykpatil/github-data-analyzer/db"
+	"github.com/ameykpatil/github-data-analyzer/domain/cluster"
+	"github.com/ameykpatil/github-data-analyzer/domain/repo"
+	"github.com/ameykpatil/github-data-analyzer/service"
+	"github.com/spf13/cobra"
+)
+
+// minProfileShare is the minimum share of an event type to be printed in the profile of a cluster
+const minProfileShare = 0.01
+
+// NewClusterCmd command to cluster the repos by activity profile
+func NewClusterCmd() *cobra.Command {
+	clusterCmd := &cobra.Command{
+		Use:   "cluster",
+		Short: "Cluster the repos by activity profile",
+		RunE:  getClusters,
+	}
+
+	clusterCmd.Flags().StringP("path", "p", "", "path of the directory where the data files are")
+	clusterCmd.Flags().IntP("k", "k", 0, fmt.Sprintf("number of clusters up to %d & the number of repos, 0 chooses it by silhouette score", cluster.MaxClusters))
+	clusterCmd.Flags().Int("max-k", cluster.DefaultMaxK, fmt.Sprintf("maximum number of clusters up to %d when choosing it by silhouette score", cluster.MaxClusters))
+	clusterCmd.Flags().Int("min-events", 1, "minimum number of events for a repo to be clustered")
+	clusterCmd.Flags().Int64("seed", cluster.DefaultSeed, "seed of the random initialisation of the clusters")
+	clusterCmd.Flags().Uint32P("limit", "l", 5, "number of representative repos per cluster, 0 returns all")
+
+	return clusterCmd
+}
+
+func getClusters(cmd *cobra.Command, args []string) error {
+	// get & verify the flags
+	path, err := cmd.Flags().GetString("path")
+	if err != nil {
+		return err
+	}
+	k, err := cmd.Flags().GetInt("k")
+	if err != nil {
+		return err
+	}
+	if k < 0 || k > cluster.MaxClusters {
+		return fmt.Errorf("k should be between 0 & %d", cluster.MaxClusters)
+	}
+	maxK, err := cmd.Flags().GetInt("max-k")
+	if err != nil {
+		return err
+	}
+	if k == 0 && (maxK < 2 || maxK > cluster.MaxClusters) {
+		return fmt.Errorf("max-k should be between 2 & %d", cluster.MaxClusters)
+	}
+	minEvents, err := cmd.Flags().GetInt("min-events")
+	if err != nil {
+		return err
+	}
+	seed, err := cmd.Flags().GetInt64("seed")
+	if err != nil {
+		return err
+	}
+	limit, err := cmd.Flags().GetUint32("limit")
+	if err != nil {
+		return err
+	}
+
+	// initialise dependencies
+	dataStore, err := db.NewDataStore(path)
+	if err != nil {
+		return err
+	}
+	eventHandler := service.NewEventHandler(dataStore)
+	repoAnalyzer := repo.NewAnalyzer(*eventHandler, repo.Options{})
+	clusterAnalyzer := cluster.NewAnalyzer(repoAnalyzer)
+
+	// cluster the repos
+	result, err := clusterAnalyzer.GetRepoClusters(cluster.Options{K: k, MaxK: maxK, MinEvents: minEvents, Seed: seed, Representatives: int(limit)})
+	if err != nil {
+		return err
+	}
+
+	// print the result in readable format
+	printClusters(result)
+
+	return nil
+}
+
+// printClusters print the clusters in readable format, with the event types of the profile above 1% share
+func printClusters(result cluster.Result) {
+	var str strings.Builder
+	for _, score := range result.Scores {
+		fmt.Fprintf(&str, "K:%d Silhouette:%.4f \n", score.K, score.Silhouette)
+	}
+	fmt.Printf("Silhouette scores of %d repos \n --- \n%s --- \n", result.Repos, str.String())
+
+	for i, c := range result.Clusters {
+		str.Reset()
+		fmt.Fprintf(&str, "Profile:")
+		for _, share := range c.Profile {
+			if share.Share >= minProfileShare {
+				fmt.Fprintf(&str, " %s:%.2f%%", share.EventType, 100*share.Share)
+			}
+		}
+		fmt.Fprintf(&str, " \n")
+		for _, r := range c.Representatives {
+			fmt.Fprintf(&str, "ID:%s Name:%s Contributors:%d Commits:%d \n", r.ID, r.Name, r.ContributorCount, r.CommitCount)
+		}
+		fmt.Printf("Cluster %d of %d, Repos:%d Share:%.2f%% \n --- \n%s --- \n", i+1, result.K, c.Size, c.Share, str.String())
+	}
+}
@@ -0,0 +1,194 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/ameykpatil/github-data-analyzer/db"
+	"github.com/ameykpatil/github-data-analyzer/domain/graph"
+	"github.com/ameykpatil/github-data-analyzer/service"
+	"github.com/spf13/cobra"
+)
+
+// NewCommunitiesCmd command to detect the communities of users who work together
+func NewCommunitiesCmd() *cobra.Command {
+	communitiesCmd := &cobra.Command{
+		Use:   "communities",
+		Short: "Detect the communities of users who work together",
+		RunE:  getCommunities,
+	}
+
+	communitiesCmd.Flags().StringP("path", "p", "", "path of the directory where the data files are")
+	communitiesCmd.Flags().StringP("format", "f", "text", "output format text, json or graphml")
+	communitiesCmd.Flags().StringP("output", "O", "", "path of the file to write the communities to, default is stdout")
+	communitiesCmd.Flags().StringP("weight", "w", "Events", "weight of the activity of a user on a repo Events, Commits or any type of Event")
+	communitiesCmd.Flags().Int("min-weight", 1, "minimum weight of the activity of a user on a repo to be a contributor")
+	communitiesCmd.Flags().Int("max-repo-users", 50, "maximum number of contributors of a repo to link them, 0 links all")
+	communitiesCmd.Flags().Int("min-size", 2, "minimum number of members of a community")
+	communitiesCmd.Flags().Uint32P("limit", "l", 10, "number of communities to return, 0 returns all")
+	communitiesCmd.Flags().Uint32("members", 10, "number of members & shared repos to print per community in text format, 0 prints all")
+
+	return communitiesCmd
+}
+
+func getCommunities(cmd *cobra.Command, args []string) error {
+	// get & verify the flags
+	path, err := cmd.Flags().GetString("path")
+	if err != nil {
+		return err
+	}
+	format, err := cmd.Flags().GetString("format")
+	if err != nil {
+		return err
+	}
+	if format != "text" && format != "json" && format != "graphml" {
+		return errors.New("invalid format " + format)
+	}
+	output, err := cmd.Flags().GetString("output")
+	if err != nil {
+		return err
+	}
+	weightField, err := cmd.Flags().GetString("weight")
+	if err != nil {
+		return err
+	}
+	weight, err := getWeightFunc(weightField)
+	if err != nil {
+		return err
+	}
+	minWeight, err := cmd.Flags().GetInt("min-weight")
+	if err != nil {
+		return err
+	}
+	maxRepoUsers, err := cmd.Flags().GetInt("max-repo-users")
+	if err != nil {
+		return err
+	}
+	minSize, err := cmd.Flags().GetInt("min-size")
+	if err != nil {
+		return err
+	}
+	limit, err := cmd.Flags().GetUint32("limit")
+	if err != nil {
+		return err
+	}
+	members, err := cmd.Flags().GetUint32("members")
+	if err != nil {
+		return err
+	}
+
+	// initialise dependencies
+	dataStore, err := db.NewDataStore(path)
+	if err != nil {
+		return err
+	}
+	eventHandler := service.NewEventHandler(dataStore)
+	graphAnalyzer := graph.NewAnalyzer(*eventHandler)
+
+	// detect the communities, the graphml export has the whole graph with all the communities
+	c := graphAnalyzer.GetCommunities(weight, graph.CommunityOptions{MinWeight: minWeight, MaxRepoUsers: maxRepoUsers, MinSize: minSize})
+
+	out := io.Writer(os.Stdout)
+	if output != "" {
+		file, err := os.Create(output)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		out = file
+	}
+
+	// write the result in given format
+	switch format {
+	case "json":
+		return writeCommunitiesJSON(out, c, int(limit))
+	case "graphml":
+		return graph.WriteCommunitiesGraphML(out, c)
+	default:
+		return writeCommunities(out, c, int(limit), int(members))
+	}
+}
+
+// writeCommunities writes the top limit communities in readable format, with at most members members & shared repos
+func writeCommunities(w io.Writer, c graph.Communities, limit, members int) error {
+	var str strings.Builder
+	fmt.Fprintf(&str, "Users:%d Links:%d Communities:%d Modularity:%.4f \n", len(c.Graph.Nodes), len(c.Graph.Edges), len(c.Communities), c.Modularity)
+	for _, community := range top(c.Communities, limit) {
+		fmt.Fprintf(&str, " --- \nCommunity:%d Size:%d SharedRepos:%d \n", community.ID, len(community.Members), len(community.SharedRepos))
+		for i, member := range community.Members {
+			if members > 0 && i == members {
+				break
+			}
+			fmt.Fprintf(&str, "Member CoContributions:%d ID:%s Username:%s \n", member.Weight, strings.TrimPrefix(member.ID, graph.UserNode+":"), member.Label)
+		}
+		for i, repo := range community.SharedRepos {
+			if members > 0 && i == members {
+				break
+			}
+			fmt.Fprintf(&str, "SharedRepo Members:%d ID:%s Name:%s \n", repo.Members, strings.TrimPrefix(repo.ID, graph.RepoNode+":"), repo.Label)
+		}
+	}
+	_, err := fmt.Fprintf(w, "Communities \n --- \n%s --- \n", str.String())
+	return err
+}
+
+// communityJSON is a community in json format
+type communityJSON struct {
+	ID          int              `json:"id"`
+	Size        int              `json:"size"`
+	Members     []memberJSON     `json:"members"`
+	SharedRepos []sharedRepoJSON `json:"sharedRepos"`
+}
+
+// memberJSON is a member of a community in json format
+type memberJSON struct {
+	ID              string `json:"id"`
+	Username        string `json:"username"`
+	CoContributions int    `json:"coContributions"`
+}
+
+// sharedRepoJSON is a repo shared by the members of a community in json format
+type sharedRepoJSON struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Members int    `json:"members"`
+}
+
+// writeCommunitiesJSON writes the top limit communities with all the members & shared repos in json format
+func writeCommunitiesJSON(w io.Writer, c graph.Communities, limit int) error {
+	out := struct {
+		Users       int             `json:"users"`
+		Links       int             `json:"links"`
+		Modularity  float64         `json:"modularity"`
+		Total       int             `json:"total"`
+		Communities []communityJSON `json:"communities"`
+	}{Users: len(c.Graph.Nodes), Links: len(c.Graph.Edges), Modularity: c.Modularity, Total: len(c.Communities), Communities: []communityJSON{}}
+	for _, community := range top(c.Communities, limit) {
+		item := communityJSON{ID: community.ID, Size: len(community.Members), Members: []memberJSON{}, SharedRepos: []sharedRepoJSON{}}
+		for _, member := range community.Members {
+			item.Members = append(item.Members, memberJSON{ID: strings.TrimPrefix(member.ID, graph.UserNode+":"), Username: member.Label, CoContributions: member.Weight})
+		}
+		for _, repo := range community.SharedRepos {
+			item.SharedRepos = append(item.SharedRepos, sharedRepoJSON{ID: strings.TrimPrefix(repo.ID, graph.RepoNode+":"), Name: repo.Label, Members: repo.Members})
+		}
+		out.Communities = append(out.Communities, item)
+	}
+	data, err := json.MarshalIndent(out, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(w, string(data))
+	return err
+}
+
+// top returns the first limit communities, limit 0 returns all
+func top(communities []graph.Community, limit int) []graph.Community {
+	if limit > 0 && limit < len(communities) {
+		return communities[:limit]
+	}
+	return communities
+}
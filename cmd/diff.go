@@ -0,0 +1,201 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/ameykpatil/github-data-analyzer/db"
+	"github.com/ameykpatil/github-data-analyzer/domain/diff"
+	"github.com/ameykpatil/github-data-analyzer/domain/influence"
+	"github.com/ameykpatil/github-data-analyzer/domain/repo"
+	"github.com/ameykpatil/github-data-analyzer/domain/user"
+	"github.com/ameykpatil/github-data-analyzer/service"
+	"github.com/spf13/cobra"
+)
+
+// NewDiffCmd command to compare two datasets
+func NewDiffCmd() *cobra.Command {
+	diffCmd := &cobra.Command{
+		Use:   "diff",
+		Short: "Compare two datasets & rank the changes",
+		RunE:  getDiff,
+	}
+
+	diffCmd.Flags().String("base", "", "path of the directory where the data files of the base dataset are")
+	diffCmd.Flags().String("head", "", "path of the directory where the data files of the head dataset are")
+	diffCmd.Flags().String("of", "users", "entities to compare, users or repos")
+	diffCmd.Flags().StringP("metric", "m", "Commits", "metric to compare")
+	diffCmd.Flags().Uint32P("limit", "l", 10, "number of changes & size of the top lists, 0 returns all")
+
+	return diffCmd
+}
+
+func getDiff(cmd *cobra.Command, args []string) error {
+	// get & verify the flags
+	basePath, err := cmd.Flags().GetString("base")
+	if err != nil {
+		return err
+	}
+	headPath, err := cmd.Flags().GetString("head")
+	if err != nil {
+		return err
+	}
+	of, err := cmd.Flags().GetString("of")
+	if err != nil {
+		return err
+	}
+	metric, err := cmd.Flags().GetString("metric")
+	if err != nil {
+		return err
+	}
+	limit, err := cmd.Flags().GetUint32("limit")
+	if err != nil {
+		return err
+	}
+	if basePath == "" || headPath == "" {
+		return errors.New("both base & head paths are required")
+	}
+	if of != "users" && of != "repos" {
+		return errors.New("invalid entities " + of)
+	}
+	userMetric, err := getUserMetric(metric)
+	if of == "users" && err != nil {
+		return err
+	}
+	repoMetric, err := getRepoMetric(metric)
+	if of == "repos" && err != nil {
+		return err
+	}
+
+	// initialise dependencies for both the datasets
+	baseUsers, baseRepos, err := newAnalyzers(basePath, metric)
+	if err != nil {
+		return err
+	}
+	headUsers, headRepos, err := newAnalyzers(headPath, metric)
+	if err != nil {
+		return err
+	}
+
+	// compare the metric & print the biggest movers
+	var changes []diff.Change
+	if of == "users" {
+		changes = diff.Compare(userValues(baseUsers.GetUsers(), userMetric), userValues(headUsers.GetUsers(), userMetric))
+	} else {
+		changes = diff.Compare(repoValues(baseRepos.GetRepos(), repoMetric), repoValues(headRepos.GetRepos(), repoMetric))
+	}
+	top := topTitle(limit)
+	printChanges(diff.GetTopChanges(changes, "", diff.Absolute, limit, 0), fmt.Sprintf("%s %s by absolute change of %s", top, of, metric))
+	printChanges(diff.GetTopChanges(changes, diff.Changed, diff.Relative, limit, 0), fmt.Sprintf("%s %s by relative change of %s", top, of, metric))
+	printChanges(diff.GetTopChanges(changes, diff.New, diff.Absolute, limit, 0), fmt.Sprintf("%s new %s by %s", top, of, metric))
+	printChanges(diff.GetTopChanges(changes, diff.Dropped, diff.Absolute, limit, 0), fmt.Sprintf("%s dropped %s by %s", top, of, metric))
+
+	// compare the top lists of all command
+	userSortFn, err := getSortFunction(allUserSortFields)
+	if err != nil {
+		return err
+	}
+	printRankChanges(diff.CompareRanks(userItems(baseUsers.GetTopUsers(0, 0, userSortFn)), userItems(headUsers.GetTopUsers(0, 0, userSortFn)), int(limit)),
+		fmt.Sprintf("Rank changes of %s users by %v", strings.ToLower(top), allUserSortFields))
+	for _, sortField := range allRepoSortFields {
+		repoSortFn, err := getRepoSortFunction([]string{sortField})
+		if err != nil {
+			return err
+		}
+		printRankChanges(diff.CompareRanks(repoItems(baseRepos.GetTopRepos(0, 0, repoSortFn)), repoItems(headRepos.GetTopRepos(0, 0, repoSortFn)), int(limit)),
+			fmt.Sprintf("Rank changes of %s repos by %v", strings.ToLower(top), []string{sortField}))
+	}
+
+	return nil
+}
+
+// newAnalyzers creates the user & repo analyzers of the dataset in the given path, with the influence scores if the metric uses them
+func newAnalyzers(path, metric string) (*user.Analyzer, *repo.Analyzer, error) {
+	dataStore, err := db.NewDataStore(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	eventHandler := service.NewEventHandler(dataStore)
+	userAnalyzer := user.NewAnalyzer(*eventHandler)
+	repoAnalyzer := repo.NewAnalyzer(*eventHandler, repo.Options{})
+	setInfluence(eventHandler, influence.Options{}, []string{metric}, userAnalyzer, repoAnalyzer)
+	return userAnalyzer, repoAnalyzer, nil
+}
+
+// userValues returns the values of the metric of the users
+func userValues(users []user.User, metric func(u user.User) float64) []diff.Value {
+	values := make([]diff.Value, 0, len(users))
+	for _, u := range users {
+		values = append(values, diff.Value{ID: u.ID, Name: u.Username, Value: metric(u)})
+	}
+	return values
+}
+
+// repoValues returns the values of the metric of the repos
+func repoValues(repos []repo.Repo, metric func(r repo.Repo) float64) []diff.Value {
+	values := make([]diff.Value, 0, len(repos))
+	for _, r := range repos {
+		values = append(values, diff.Value{ID: r.ID, Name: r.Name, Value: metric(r)})
+	}
+	return values
+}
+
+// userItems returns the ranked users as items
+func userItems(users []user.User) []diff.Item {
+	items := make([]diff.Item, 0, len(users))
+	for _, u := range users {
+		items = append(items, diff.Item{ID: u.ID, Name: u.Username})
+	}
+	return items
+}
+
+// repoItems returns the ranked repos as items
+func repoItems(repos []repo.Repo) []diff.Item {
+	items := make([]diff.Item, 0, len(repos))
+	for _, r := range repos {
+		items = append(items, diff.Item{ID: r.ID, Name: r.Name})
+	}
+	return items
+}
+
+// printChanges print the changes in readable format
+func printChanges(changes []diff.Change, title string) {
+	var str strings.Builder
+	for _, change := range changes {
+		relative := "-"
+		if !math.IsInf(change.Relative, 0) {
+			relative = fmt.Sprintf("%+.2f%%", 100*change.Relative)
+		}
+		fmt.Fprintf(&str, "Delta:%+g Relative:%s Base:%g Head:%g Status:%s ID:%s Name:%s \n",
+			change.Delta, relative, change.Base, change.Head, change.Status, change.ID, change.Name)
+	}
+	fmt.Printf("%s \n --- \n%s --- \n", title, str.String())
+}
+
+// printRankChanges print the rank changes in readable format, a rank is - if not in that dataset
+func printRankChanges(changes []diff.RankChange, title string) {
+	var str strings.Builder
+	for _, change := range changes {
+		fmt.Fprintf(&str, "Rank:%s Base:%s Change:%+d Status:%s ID:%s Name:%s \n",
+			rankString(change.HeadRank), rankString(change.BaseRank), change.Change, change.Status, change.ID, change.Name)
+	}
+	fmt.Printf("%s \n --- \n%s --- \n", title, str.String())
+}
+
+// topTitle returns the title of the top lists of the limit, All when there is no limit
+func topTitle(limit uint32) string {
+	if limit == 0 {
+		return "All"
+	}
+	return fmt.Sprintf("Top %d", limit)
+}
+
+// rankString returns the rank or - if there is no rank
+func rankString(rank int) string {
+	if rank == 0 {
+		return "-"
+	}
+	return fmt.Sprint(rank)
+}
@@ -0,0 +1,153 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ameykpatil/github-data-analyzer/db"
+	"github.com/ameykpatil/github-data-analyzer/domain/distribution"
+	"github.com/ameykpatil/github-data-analyzer/domain/influence"
+	"github.com/ameykpatil/github-data-analyzer/domain/repo"
+	"github.com/ameykpatil/github-data-analyzer/domain/user"
+	"github.com/ameykpatil/github-data-analyzer/service"
+	"github.com/spf13/cobra"
+)
+
+// histogramWidth is the number of characters of the longest bar of the ascii histogram
+const histogramWidth = 50
+
+// NewDistributionCmd command to get the distribution of a metric
+func NewDistributionCmd() *cobra.Command {
+	distributionCmd := &cobra.Command{
+		Use:   "distribution",
+		Short: "Get the distribution of a metric of users or repos",
+		RunE:  getDistribution,
+	}
+
+	distributionCmd.Flags().StringP("path", "p", "", "path of the directory where the data files are")
+	distributionCmd.Flags().String("of", "users", "entities to compute the distribution of, users or repos")
+	distributionCmd.Flags().StringP("metric", "m", "Commits", "metric to compute the distribution of")
+	distributionCmd.Flags().StringP("format", "f", "table", "output format table, json or histogram")
+
+	return distributionCmd
+}
+
+func getDistribution(cmd *cobra.Command, args []string) error {
+	// get & verify the flags
+	path, err := cmd.Flags().GetString("path")
+	if err != nil {
+		return err
+	}
+	of, err := cmd.Flags().GetString("of")
+	if err != nil {
+		return err
+	}
+	metric, err := cmd.Flags().GetString("metric")
+	if err != nil {
+		return err
+	}
+	format, err := cmd.Flags().GetString("format")
+	if err != nil {
+		return err
+	}
+	if format != "table" && format != "json" && format != "histogram" {
+		return errors.New("invalid format " + format)
+	}
+	if of != "users" && of != "repos" {
+		return errors.New("invalid entities " + of)
+	}
+
+	// initialise dependencies
+	dataStore, err := db.NewDataStore(path)
+	if err != nil {
+		return err
+	}
+	eventHandler := service.NewEventHandler(dataStore)
+
+	// get the values of the metric for all the users or repos
+	var values []float64
+	if of == "users" {
+		value, err := getUserMetric(metric)
+		if err != nil {
+			return err
+		}
+		userAnalyzer := user.NewAnalyzer(*eventHandler)
+		setInfluence(eventHandler, influence.Options{}, []string{metric}, userAnalyzer, nil)
+		for _, u := range userAnalyzer.GetUsers() {
+			values = append(values, value(u))
+		}
+	} else {
+		value, err := getRepoMetric(metric)
+		if err != nil {
+			return err
+		}
+		repoAnalyzer := repo.NewAnalyzer(*eventHandler, repo.Options{})
+		setInfluence(eventHandler, influence.Options{}, []string{metric}, nil, repoAnalyzer)
+		for _, r := range repoAnalyzer.GetRepos() {
+			values = append(values, value(r))
+		}
+	}
+	d := distribution.Compute(values)
+
+	// print the result in given format
+	switch format {
+	case "json":
+		return printDistributionJSON(of, metric, d)
+	case "histogram":
+		printHistogram(of, metric, d)
+	default:
+		printDistribution(of, metric, d)
+	}
+
+	return nil
+}
+
+// printDistribution print the distribution in readable format
+func printDistribution(of, metric string, d distribution.Distribution) {
+	var str strings.Builder
+	fmt.Fprintf(&str, "Count:%d Mean:%.2f Median:%s P90:%s P99:%s Max:%s \n",
+		d.Count, d.Mean, formatMetric(d.Median), formatMetric(d.P90), formatMetric(d.P99), formatMetric(d.Max))
+	for _, bin := range d.Histogram {
+		fmt.Fprintf(&str, "[%g,%g):%d \n", bin.Lower, bin.Upper, bin.Count)
+	}
+	fmt.Printf("Distribution of %s of %s \n --- \n%s --- \n", metric, of, str.String())
+}
+
+// printHistogram print the histogram of the distribution with ascii bars
+func printHistogram(of, metric string, d distribution.Distribution) {
+	maxCount := 0
+	for _, bin := range d.Histogram {
+		if bin.Count > maxCount {
+			maxCount = bin.Count
+		}
+	}
+
+	var str strings.Builder
+	for _, bin := range d.Histogram {
+		width := 0
+		if maxCount > 0 {
+			width = bin.Count * histogramWidth / maxCount
+		}
+		if width == 0 && bin.Count > 0 {
+			width = 1
+		}
+		fmt.Fprintf(&str, "%14s | %-*s %d \n", fmt.Sprintf("[%g,%g)", bin.Lower, bin.Upper), histogramWidth, strings.Repeat("#", width), bin.Count)
+	}
+	fmt.Printf("Histogram of %s of %s \n --- \n%s --- \n", metric, of, str.String())
+}
+
+// printDistributionJSON print the distribution in json format
+func printDistributionJSON(of, metric string, d distribution.Distribution) error {
+	out, err := json.MarshalIndent(struct {
+		Of           string                    `json:"of"`
+		Metric       string                    `json:"metric"`
+		Distribution distribution.Distribution `json:"distribution"`
+	}{of, metric, d}, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(out))
+	return nil
+}
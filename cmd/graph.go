@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"errors"
+	"io"
+	"os"
+
+	"github.com/ameykpatil/github-data-analyzer/db"
+	"github.com/ameykpatil/github-data-analyzer/domain/graph"
+	"github.com/ameykpatil/github-data-analyzer/service"
+	"github.com/spf13/cobra"
+)
+
+// NewGraphCmd command to export the user-repo collaboration graph
+func NewGraphCmd() *cobra.Command {
+	graphCmd := &cobra.Command{
+		Use:   "graph",
+		Short: "Export the user-repo collaboration graph",
+		RunE:  exportGraph,
+	}
+
+	graphCmd.Flags().StringP("path", "p", "", "path of the directory where the data files are")
+	graphCmd.Flags().StringP("format", "f", "graphml", "export format graphml, gexf or dot")
+	graphCmd.Flags().StringP("output", "O", "", "path of the file to write the graph to, default is stdout")
+	graphCmd.Flags().StringP("weight", "w", "Events", "edge weight Events, Commits or any type of Event")
+	graphCmd.Flags().Int("min-weight", 1, "minimum weight of the edges to keep")
+	graphCmd.Flags().Uint32("top", 0, "number of nodes with highest weighted degree to keep with their neighbours, 0 keeps all")
+
+	return graphCmd
+}
+
+func exportGraph(cmd *cobra.Command, args []string) error {
+	// get & verify the flags
+	path, err := cmd.Flags().GetString("path")
+	if err != nil {
+		return err
+	}
+	format, err := cmd.Flags().GetString("format")
+	if err != nil {
+		return err
+	}
+	output, err := cmd.Flags().GetString("output")
+	if err != nil {
+		return err
+	}
+	weightField, err := cmd.Flags().GetString("weight")
+	if err != nil {
+		return err
+	}
+	minWeight, err := cmd.Flags().GetInt("min-weight")
+	if err != nil {
+		return err
+	}
+	top, err := cmd.Flags().GetUint32("top")
+	if err != nil {
+		return err
+	}
+
+	// create write function based on format
+	var write func(w io.Writer, g *graph.Graph) error
+	switch format {
+	case "graphml":
+		write = graph.WriteGraphML
+	case "gexf":
+		write = graph.WriteGEXF
+	case "dot":
+		write = graph.WriteDOT
+	default:
+		return errors.New("invalid format " + format)
+	}
+
+	// create weight function based on weight field
+	weight, err := getWeightFunc(weightField)
+	if err != nil {
+		return err
+	}
+
+	// initialise dependencies
+	dataStore, err := db.NewDataStore(path)
+	if err != nil {
+		return err
+	}
+	eventHandler := service.NewEventHandler(dataStore)
+	graphAnalyzer := graph.NewAnalyzer(*eventHandler)
+
+	// build the graph & write it in the given format
+	g := graphAnalyzer.GetGraph(weight, minWeight, top)
+
+	out := io.Writer(os.Stdout)
+	if output != "" {
+		file, err := os.Create(output)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		out = file
+	}
+
+	return write(out, g)
+}
+
+// getWeightFunc returns the function to get the weight of an edge of the user-repo graph by the weight field
+func getWeightFunc(weightField string) (graph.WeightFunc, error) {
+	switch weightField {
+	case "Events":
+		return func(edge graph.Edge) int {
+			return edge.EventCount
+		}, nil
+	case "Commits":
+		return func(edge graph.Edge) int {
+			return edge.CommitCount
+		}, nil
+	default:
+		if !service.IsEventType(weightField) {
+			return nil, errors.New("invalid weight field " + weightField)
+		}
+		return func(edge graph.Edge) int {
+			return edge.EventTypeCount[weightField]
+		}, nil
+	}
+}
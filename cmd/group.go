@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/ameykpatil/github-data-analyzer/domain/persona"
+	"github.com/ameykpatil/github-data-analyzer/domain/repo"
+	"github.com/ameykpatil/github-data-analyzer/domain/user"
+	"github.com/spf13/cobra"
+)
+
+// addFormatFlag adds the flag of the output format, text or json
+func addFormatFlag(cmd *cobra.Command) {
+	cmd.Flags().StringP("format", "f", "text", "output format text or json")
+}
+
+// getFormat returns the output format, text or json
+func getFormat(cmd *cobra.Command) (string, error) {
+	format, err := cmd.Flags().GetString("format")
+	if err != nil {
+		return "", err
+	}
+	if format != "text" && format != "json" {
+		return "", errors.New("invalid format " + format)
+	}
+	return format, nil
+}
+
+// sortValueJSON is the value of a sort field in json format
+type sortValueJSON struct {
+	Field string  `json:"field"`
+	Value float64 `json:"value"`
+}
+
+// repoActivityJSON is the activity of a user on a repo in json format
+type repoActivityJSON struct {
+	ID         string         `json:"id"`
+	Name       string         `json:"name"`
+	Events     int            `json:"events"`
+	Commits    int            `json:"commits"`
+	EventTypes map[string]int `json:"eventTypes,omitempty"`
+}
+
+// userJSON is a ranked user with its top repos in json format
+type userJSON struct {
+	Rank     int                `json:"rank"`
+	Tie      bool               `json:"tie"`
+	ID       string             `json:"id"`
+	Username string             `json:"username"`
+	Persona  persona.Persona    `json:"persona"`
+	Values   []sortValueJSON    `json:"values"`
+	Repos    []repoActivityJSON `json:"repos,omitempty"`
+}
+
+// contributorJSON is a contributor of a repo in json format
+type contributorJSON struct {
+	ID       string `json:"id"`
+	Username string `json:"username"`
+	Commits  int    `json:"commits"`
+	Events   int    `json:"events"`
+}
+
+// repoJSON is a ranked repo with its top contributors in json format
+type repoJSON struct {
+	Rank         int               `json:"rank"`
+	Tie          bool              `json:"tie"`
+	ID           string            `json:"id"`
+	Name         string            `json:"name"`
+	Values       []sortValueJSON   `json:"values"`
+	Contributors []contributorJSON `json:"contributors,omitempty"`
+}
+
+// printUsersJSON print users with their top repos (if any) in json format
+func printUsersJSON(users []user.RankedUser, sortFields []string, reposPerUser map[string][]user.RepoActivity, thresholds persona.Thresholds) error {
+	columns, err := getUserColumns(sortFields)
+	if err != nil {
+		return err
+	}
+
+	out := make([]userJSON, 0, len(users))
+	for _, u := range users {
+		item := userJSON{Rank: u.Rank, Tie: u.Tie, ID: u.ID, Username: u.Username, Persona: persona.Classify(u.User, thresholds), Values: []sortValueJSON{}}
+		for i, column := range columns.columns {
+			item.Values = append(item.Values, sortValueJSON{Field: column.label, Value: columns.values[i](u.User)})
+		}
+		for _, activity := range reposPerUser[u.ID] {
+			item.Repos = append(item.Repos, repoActivityJSON{ID: activity.ID, Name: activity.Name, Events: activity.EventCount, Commits: activity.CommitCount})
+		}
+		out = append(out, item)
+	}
+	return printJSON(out)
+}
+
+// printReposJSON print repos with their top contributors (if any) in json format
+func printReposJSON(repos []repo.RankedRepo, sortFields []string, contributorsPerRepo map[string][]repo.Contributor) error {
+	columns, err := getRepoColumns(sortFields)
+	if err != nil {
+		return err
+	}
+
+	out := make([]repoJSON, 0, len(repos))
+	for _, r := range repos {
+		item := repoJSON{Rank: r.Rank, Tie: r.Tie, ID: r.ID, Name: r.Name, Values: []sortValueJSON{}}
+		for i, column := range columns.columns {
+			item.Values = append(item.Values, sortValueJSON{Field: column.label, Value: columns.values[i](r.Repo)})
+		}
+		for _, contributor := range contributorsPerRepo[r.ID] {
+			item.Contributors = append(item.Contributors, contributorJSON{ID: contributor.ID, Username: contributor.Username, Commits: contributor.CommitCount, Events: contributor.EventCount})
+		}
+		out = append(out, item)
+	}
+	return printJSON(out)
+}
+
+// printJSON print the value in indented json format
+func printJSON(v interface{}) error {
+	out, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(out))
+	return nil
+}
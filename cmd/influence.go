@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"errors"
+
+	"github.com/ameykpatil/github-data-analyzer/domain/influence"
+	"github.com/ameykpatil/github-data-analyzer/domain/repo"
+	"github.com/ameykpatil/github-data-analyzer/domain/user"
+	"github.com/ameykpatil/github-data-analyzer/service"
+	"github.com/spf13/cobra"
+)
+
+// addInfluenceFlags adds the flags to configure the influence scores to the command
+func addInfluenceFlags(cmd *cobra.Command) {
+	cmd.Flags().Float64("damping", influence.DefaultDamping, "damping factor of the influence scores, greater than 0 & less than 1")
+	cmd.Flags().StringToString("edge-weights", map[string]string{}, "edge weights of the event types in the influence graph e.g. WatchEvent=0.5,PushEvent=2")
+}
+
+// getInfluenceOptions returns the options of the influence scores from the flags of the command
+func getInfluenceOptions(cmd *cobra.Command) (influence.Options, error) {
+	damping, err := cmd.Flags().GetFloat64("damping")
+	if err != nil {
+		return influence.Options{}, err
+	}
+	if damping <= 0 || damping >= 1 {
+		return influence.Options{}, errors.New("damping should be greater than 0 & less than 1")
+	}
+	specs, err := cmd.Flags().GetStringToString("edge-weights")
+	if err != nil {
+		return influence.Options{}, err
+	}
+	weights, err := influence.ParseWeights(specs)
+	if err != nil {
+		return influence.Options{}, err
+	}
+	return influence.Options{Damping: damping, Weights: weights}, nil
+}
+
+// usesInfluence reports whether any of the fields (sort fields, metrics or score expressions) uses the Influence metric
+func usesInfluence(fields []string) bool {
+	for _, field := range fields {
+		key := parseSortKey(field)
+		names := []string{key.field}
+		if isScoreField(key.field) {
+			_, expression, err := parseScoreField(key.field)
+			if err != nil {
+				continue
+			}
+			names = expression.Variables()
+		}
+		for _, name := range names {
+			if resolveAlias(name) == "Influence" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// setInfluence computes the influence scores & sets them to the users & repos of the given analyzers (if not nil)
+// the scores are computed only if any of the fields uses them, as it is a walk over the whole graph
+func setInfluence(eventHandler *service.EventHandler, options influence.Options, fields []string, userAnalyzer *user.Analyzer, repoAnalyzer *repo.Analyzer) {
+	if !usesInfluence(fields) {
+		return
+	}
+	scores := influence.Compute(*eventHandler, options)
+	if userAnalyzer != nil {
+		userAnalyzer.SetInfluence(scores.Users)
+	}
+	if repoAnalyzer != nil {
+		repoAnalyzer.SetInfluence(scores.Repos)
+	}
+}
@@ -0,0 +1,14 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUsesInfluence(t *testing.T) {
+	assert.True(t, usesInfluence([]string{"Commits", "-Influence"}))
+	assert.True(t, usesInfluence([]string{"score=2*Influence+prs"}))
+	assert.False(t, usesInfluence([]string{"Commits", "prs", "score=Commits+1"}))
+	assert.False(t, usesInfluence(nil))
+}
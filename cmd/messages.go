@@ -0,0 +1,164 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ameykpatil/github-data-analyzer/db"
+	"github.com/ameykpatil/github-data-analyzer/domain/message"
+	"github.com/ameykpatil/github-data-analyzer/service"
+	"github.com/spf13/cobra"
+)
+
+// NewMessagesCmd command to get the commit message analytics
+func NewMessagesCmd() *cobra.Command {
+	messagesCmd := &cobra.Command{
+		Use:   "messages",
+		Short: "Get the commit message analytics",
+		RunE:  getMessageStats,
+	}
+
+	messagesCmd.Flags().StringP("path", "p", "", "path of the directory where the data files are")
+	messagesCmd.Flags().StringP("by", "b", "", "analyze the commit messages per user or repo")
+	messagesCmd.Flags().StringP("sort", "s", "Hygiene", "field to sort the users or repos by")
+	messagesCmd.Flags().Uint32P("limit", "l", 10, "number of users or repos to return, 0 returns all")
+	messagesCmd.Flags().Uint32P("offset", "o", 0, "number of top users or repos to skip")
+	messagesCmd.Flags().Uint32("min-commits", 5, "minimum number of commits for a user or repo to be ranked")
+	messagesCmd.Flags().Uint32("terms", 10, "number of most common leading words & n-grams to return, 0 returns all")
+	messagesCmd.Flags().Int("ngram", 2, "number of words in an n-gram")
+
+	return messagesCmd
+}
+
+func getMessageStats(cmd *cobra.Command, args []string) error {
+	// get & verify the flags
+	path, err := cmd.Flags().GetString("path")
+	if err != nil {
+		return err
+	}
+	by, err := cmd.Flags().GetString("by")
+	if err != nil {
+		return err
+	}
+	sortField, err := cmd.Flags().GetString("sort")
+	if err != nil {
+		return err
+	}
+	limit, err := cmd.Flags().GetUint32("limit")
+	if err != nil {
+		return err
+	}
+	offset, err := cmd.Flags().GetUint32("offset")
+	if err != nil {
+		return err
+	}
+	minCommits, err := cmd.Flags().GetUint32("min-commits")
+	if err != nil {
+		return err
+	}
+	terms, err := cmd.Flags().GetUint32("terms")
+	if err != nil {
+		return err
+	}
+	ngram, err := cmd.Flags().GetInt("ngram")
+	if err != nil {
+		return err
+	}
+	if ngram < 1 {
+		return errors.New("ngram should be at least 1")
+	}
+
+	// create sort function based on sort field
+	var fn func(si, sj message.Stats) bool
+	switch sortField {
+	case "Commits":
+		fn = func(si, sj message.Stats) bool {
+			return si.CommitCount > sj.CommitCount
+		}
+	case "Hygiene":
+		fn = func(si, sj message.Stats) bool {
+			return si.Hygiene() > sj.Hygiene()
+		}
+	case "Conventional":
+		fn = func(si, sj message.Stats) bool {
+			return si.Share(si.ConventionalCount) > sj.Share(sj.ConventionalCount)
+		}
+	case "Placeholder":
+		fn = func(si, sj message.Stats) bool {
+			return si.Share(si.PlaceholderCount) > sj.Share(sj.PlaceholderCount)
+		}
+	case "Revert":
+		fn = func(si, sj message.Stats) bool {
+			return si.Share(si.RevertCount) > sj.Share(sj.RevertCount)
+		}
+	case "Merge":
+		fn = func(si, sj message.Stats) bool {
+			return si.Share(si.MergeCount) > sj.Share(sj.MergeCount)
+		}
+	case "MedianLength":
+		fn = func(si, sj message.Stats) bool {
+			return si.MedianLength > sj.MedianLength
+		}
+	default:
+		return errors.New("invalid sort field " + sortField)
+	}
+
+	// create the key function based on breakdown
+	var key message.KeyFunc
+	switch by {
+	case "":
+	case "user":
+		key = message.ByUser
+	case "repo":
+		key = message.ByRepo
+	default:
+		return errors.New("invalid breakdown " + by)
+	}
+
+	// initialise dependencies
+	dataStore, err := db.NewDataStore(path)
+	if err != nil {
+		return err
+	}
+	eventHandler := service.NewEventHandler(dataStore)
+	messageAnalyzer := message.NewAnalyzer(*eventHandler, message.Options{Terms: int(terms), NGram: ngram})
+
+	// get the stats & print the result in readable format
+	if key == nil {
+		printMessageStats([]message.Stats{messageAnalyzer.GetStats()}, "Commit Messages")
+		return nil
+	}
+	stats := messageAnalyzer.GetGroupStats(key, minCommits, limit, offset, fn)
+	printMessageStats(stats, fmt.Sprintf("Top %d %ss by %s", limit, strings.ToUpper(by[:1])+by[1:], sortField))
+
+	return nil
+}
+
+// printMessageStats print commit message stats in readable format
+func printMessageStats(stats []message.Stats, title string) {
+	var str strings.Builder
+	for _, s := range stats {
+		if s.ID != "all" {
+			fmt.Fprintf(&str, "ID:%s Name:%s \n", s.ID, s.Name)
+		}
+		fmt.Fprintf(&str, "Commits:%d Hygiene:%.2f%% Conventional:%.2f%% Revert:%.2f%% Merge:%.2f%% Placeholder:%.2f%% \n",
+			s.CommitCount, s.Hygiene()*100, s.Share(s.ConventionalCount)*100, s.Share(s.RevertCount)*100,
+			s.Share(s.MergeCount)*100, s.Share(s.PlaceholderCount)*100)
+		fmt.Fprintf(&str, "MedianLength:%d MeanLength:%.1f Lengths:", s.MedianLength, s.MeanLength)
+		for _, bucket := range s.Lengths {
+			fmt.Fprintf(&str, " %s=%d", bucket.Label, bucket.Count)
+		}
+		fmt.Fprintf(&str, " \nLeadingWords:%s \nNGrams:%s \n", formatTerms(s.LeadingWords), formatTerms(s.NGrams))
+	}
+	fmt.Printf("%s \n --- \n%s --- \n", title, str.String())
+}
+
+// formatTerms formats the terms with their counts
+func formatTerms(terms []message.Term) string {
+	var str strings.Builder
+	for _, term := range terms {
+		fmt.Fprintf(&str, " %q=%d", term.Text, term.Count)
+	}
+	return str.String()
+}
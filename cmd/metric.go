@@ -0,0 +1,136 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/ameykpatil/github-data-analyzer/domain/org"
+	"github.com/ameykpatil/github-data-analyzer/domain/repo"
+	"github.com/ameykpatil/github-data-analyzer/domain/user"
+	"github.com/ameykpatil/github-data-analyzer/service"
+)
+
+// getUserMetric returns the function to get the value of given metric (or its alias) of a user
+// scoped metrics e.g. External:Commits are computed on the activity of the scope
+func getUserMetric(metric string) (func(u user.User) float64, error) {
+	metric = resolveAlias(metric)
+	if scope, scopedMetric := splitScope(metric); scope != "" {
+		if _, ok := (user.User{}).Scoped(scope); !ok {
+			return nil, unknownFieldError(metric, []string{user.OwnScope + ":" + scopedMetric, user.ExternalScope + ":" + scopedMetric})
+		}
+		if scopedMetric == "Influence" {
+			return nil, errors.New("metric Influence has no scope")
+		}
+		value, err := getUserMetric(scopedMetric)
+		if err != nil {
+			return nil, err
+		}
+		return func(u user.User) float64 {
+			scoped, _ := u.Scoped(scope)
+			return value(scoped)
+		}, nil
+	}
+
+	switch metric {
+	case "Commits":
+		return func(u user.User) float64 { return float64(u.CommitCount) }, nil
+	case "DistinctCommits":
+		return func(u user.User) float64 { return float64(u.DistinctCommitCount) }, nil
+	case "Repos":
+		return func(u user.User) float64 { return float64(u.RepoCount) }, nil
+	case "Influence":
+		return func(u user.User) float64 { return u.Influence }, nil
+	default:
+		if eventType := strings.TrimPrefix(metric, "Repos."); eventType != metric && service.IsEventType(eventType) {
+			return func(u user.User) float64 { return float64(u.EventTypeRepoCount[eventType]) }, nil
+		}
+		if service.IsEventType(metric) {
+			return func(u user.User) float64 { return float64(u.EventTypeCount[metric]) }, nil
+		}
+		return nil, unknownFieldError(metric, userMetricNames())
+	}
+}
+
+// getRepoMetric returns the function to get the value of given metric (or its alias) of a repo
+func getRepoMetric(metric string) (func(r repo.Repo) float64, error) {
+	metric = resolveAlias(metric)
+	switch metric {
+	case "Commits":
+		return func(r repo.Repo) float64 { return float64(r.CommitCount) }, nil
+	case "DistinctCommits":
+		return func(r repo.Repo) float64 { return float64(r.DistinctCommitCount) }, nil
+	case "Contributors", "Actors":
+		return func(r repo.Repo) float64 { return float64(r.ContributorCount) }, nil
+	case "BusFactor":
+		return func(r repo.Repo) float64 { return float64(r.BusFactor) }, nil
+	case "Gini":
+		return func(r repo.Repo) float64 { return r.Gini }, nil
+	case "Herfindahl":
+		return func(r repo.Repo) float64 { return r.Herfindahl }, nil
+	case "Influence":
+		return func(r repo.Repo) float64 { return r.Influence }, nil
+	default:
+		if eventType := strings.TrimPrefix(metric, "Actors."); eventType != metric && service.IsEventType(eventType) {
+			return func(r repo.Repo) float64 { return float64(r.EventTypeActorCount[eventType]) }, nil
+		}
+		if service.IsEventType(metric) {
+			return func(r repo.Repo) float64 { return float64(r.EventTypeCount[metric]) }, nil
+		}
+		return nil, unknownFieldError(metric, repoMetricNames())
+	}
+}
+
+// getOrgMetric returns the function to get the value of given metric (or its alias) of an org
+func getOrgMetric(metric string) (func(o org.Org) float64, error) {
+	metric = resolveAlias(metric)
+	switch metric {
+	case "Commits":
+		return func(o org.Org) float64 { return float64(o.CommitCount) }, nil
+	case "DistinctCommits":
+		return func(o org.Org) float64 { return float64(o.DistinctCommitCount) }, nil
+	case "Repos":
+		return func(o org.Org) float64 { return float64(o.RepoCount) }, nil
+	case "Contributors":
+		return func(o org.Org) float64 { return float64(o.ContributorCount) }, nil
+	default:
+		if service.IsEventType(metric) {
+			return func(o org.Org) float64 { return float64(o.EventTypeCount[metric]) }, nil
+		}
+		return nil, unknownFieldError(metric, orgMetricNames())
+	}
+}
+
+// userMetricNames returns the names of the metrics of a user without scope
+func userMetricNames() []string {
+	names := []string{"Commits", "DistinctCommits", "Repos", "Influence"}
+	names = append(names, service.EventTypes...)
+	for _, eventType := range service.EventTypes {
+		names = append(names, "Repos."+eventType)
+	}
+	return names
+}
+
+// repoMetricNames returns the names of the metrics of a repo
+func repoMetricNames() []string {
+	names := []string{"Commits", "DistinctCommits", "Contributors", "Actors", "BusFactor", "Gini", "Herfindahl", "Influence"}
+	names = append(names, service.EventTypes...)
+	for _, eventType := range service.EventTypes {
+		names = append(names, "Actors."+eventType)
+	}
+	return names
+}
+
+// orgMetricNames returns the names of the metrics of an org
+func orgMetricNames() []string {
+	return append([]string{"Commits", "DistinctCommits", "Repos", "Contributors"}, service.EventTypes...)
+}
+
+// formatMetric formats the value of a metric, counts are printed as integers & ratios with 4 decimals
+func formatMetric(value float64) string {
+	if value == math.Trunc(value) {
+		return fmt.Sprintf("%d", int64(value))
+	}
+	return fmt.Sprintf("%.4f", value)
+}
@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/ameykpatil/github-data-analyzer/domain/org"
+	"github.com/ameykpatil/github-data-analyzer/domain/repo"
+	"github.com/ameykpatil/github-data-analyzer/domain/user"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetMetricAlias(t *testing.T) {
+	u := user.User{CommitCount: 3, EventTypeCount: map[string]int{"PullRequestEvent": 2}}
+	r := repo.Repo{CommitCount: 4, EventTypeCount: map[string]int{"WatchEvent": 5}}
+	o := org.Org{EventTypeCount: map[string]int{"ForkEvent": 6}}
+
+	tests := []struct {
+		metric string
+		user   float64
+		repo   float64
+	}{
+		{metric: "prs", user: 2, repo: 0},
+		{metric: "PRs", user: 2, repo: 0},
+		{metric: "stars", user: 0, repo: 5},
+		{metric: "commits", user: 3, repo: 4},
+		{metric: "PullRequestEvent", user: 2, repo: 0},
+	}
+	for _, test := range tests {
+		userMetric, err := getUserMetric(test.metric)
+		assert.NoError(t, err, test.metric)
+		assert.Equal(t, test.user, userMetric(u), test.metric)
+
+		repoMetric, err := getRepoMetric(test.metric)
+		assert.NoError(t, err, test.metric)
+		assert.Equal(t, test.repo, repoMetric(r), test.metric)
+	}
+
+	orgMetric, err := getOrgMetric("forks")
+	assert.NoError(t, err)
+	assert.Equal(t, 6.0, orgMetric(o))
+
+	// the alias is resolved after the scope too
+	_, err = getUserMetric("External:prs")
+	assert.NoError(t, err)
+
+	// unknown fields suggest an alias which is accepted
+	_, err = getUserMetric("prz")
+	assert.EqualError(t, err, "unknown field prz, did you mean prs?")
+}
+
+func TestParseSortKey(t *testing.T) {
+	tests := []struct {
+		spec string
+		want sortKey
+	}{
+		{spec: "prs", want: sortKey{field: "PullRequestEvent"}},
+		{spec: "+stars", want: sortKey{field: "WatchEvent", ascending: true}},
+		{spec: "Own:commits:asc", want: sortKey{field: "Own:Commits", ascending: true}},
+		{spec: "-Repos", want: sortKey{field: "Repos"}},
+		{spec: "s=prs+1", want: sortKey{field: "s=prs+1"}},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.want, parseSortKey(test.spec), test.spec)
+	}
+}
+
+func TestSortFunctions(t *testing.T) {
+	sortFields := []string{"prs", "+commits"}
+
+	userFn, err := getSortFunction(sortFields)
+	assert.NoError(t, err)
+	repoFn, err := getRepoSortFunction(sortFields)
+	assert.NoError(t, err)
+	orgFn, err := getOrgSortFunction([]string{"prs", "+Repos"})
+	assert.NoError(t, err)
+
+	tests := []struct {
+		iPRs, jPRs, iCommits, jCommits int
+		want                           bool
+	}{
+		// more prs come first
+		{iPRs: 2, jPRs: 1, iCommits: 9, jCommits: 1, want: true},
+		{iPRs: 1, jPRs: 2, iCommits: 1, jCommits: 9, want: false},
+		// the tie in prs is broken by the fewer commits
+		{iPRs: 1, jPRs: 1, iCommits: 1, jCommits: 2, want: true},
+		{iPRs: 1, jPRs: 1, iCommits: 2, jCommits: 1, want: false},
+		// equal in all the fields is not less
+		{iPRs: 1, jPRs: 1, iCommits: 1, jCommits: 1, want: false},
+	}
+	for _, test := range tests {
+		ui := user.User{CommitCount: test.iCommits, EventTypeCount: map[string]int{"PullRequestEvent": test.iPRs}}
+		uj := user.User{CommitCount: test.jCommits, EventTypeCount: map[string]int{"PullRequestEvent": test.jPRs}}
+		assert.Equal(t, test.want, userFn(ui, uj), "%+v", test)
+
+		ri := repo.Repo{CommitCount: test.iCommits, EventTypeCount: map[string]int{"PullRequestEvent": test.iPRs}}
+		rj := repo.Repo{CommitCount: test.jCommits, EventTypeCount: map[string]int{"PullRequestEvent": test.jPRs}}
+		assert.Equal(t, test.want, repoFn(ri, rj), "%+v", test)
+
+		oi := org.Org{RepoCount: test.iCommits, EventTypeCount: map[string]int{"PullRequestEvent": test.iPRs}}
+		oj := org.Org{RepoCount: test.jCommits, EventTypeCount: map[string]int{"PullRequestEvent": test.jPRs}}
+		assert.Equal(t, test.want, orgFn(oi, oj), "%+v", test)
+	}
+
+	_, err = getOrgSortFunction([]string{"prz"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ameykpatil/github-data-analyzer/db"
+	"github.com/ameykpatil/github-data-analyzer/domain/org"
+	"github.com/ameykpatil/github-data-analyzer/service"
+	"github.com/spf13/cobra"
+)
+
+// NewOrgsCmd command to get top orgs (repo owners)
+func NewOrgsCmd() *cobra.Command {
+	orgsCmd := &cobra.Command{
+		Use:   "orgs",
+		Short: "Get the top orgs (repo owners)",
+		RunE:  getTopOrgs,
+	}
+
+	orgsCmd.Flags().StringP("path", "p", "", "path of the directory where the data files are")
+	orgsCmd.Flags().Uint32P("limit", "l", 10, "number of orgs to return, 0 returns all")
+	orgsCmd.Flags().Uint32P("offset", "o", 0, "number of top orgs to skip")
+	orgsCmd.Flags().StringSliceP("sort", "s", []string{"Commits"}, "fields to sort by, descending unless prefixed with + or suffixed with :asc")
+
+	return orgsCmd
+}
+
+func getTopOrgs(cmd *cobra.Command, args []string) error {
+	// get & verify the flags
+	path, err := cmd.Flags().GetString("path")
+	if err != nil {
+		return err
+	}
+	limit, err := cmd.Flags().GetUint32("limit")
+	if err != nil {
+		return err
+	}
+	offset, err := cmd.Flags().GetUint32("offset")
+	if err != nil {
+		return err
+	}
+	sortFields, err := cmd.Flags().GetStringSlice("sort")
+	if err != nil {
+		return err
+	}
+
+	// create custom sort function
+	fn, err := getOrgSortFunction(sortFields)
+	if err != nil {
+		return err
+	}
+
+	// initialise dependencies
+	dataStore, err := db.NewDataStore(path)
+	if err != nil {
+		return err
+	}
+	eventHandler := service.NewEventHandler(dataStore)
+	orgAnalyzer := org.NewAnalyzer(*eventHandler)
+
+	// get the top orgs
+	orgs := orgAnalyzer.GetTopOrgs(limit, offset, fn)
+
+	// print the result in readable format
+	return printOrgs(orgs, limit, sortFields)
+}
+
+// getOrgSortFunction creates multilevel sort function based on sortFields
+func getOrgSortFunction(sortFields []string) (func(oi, oj org.Org) bool, error) {
+	columns, err := getOrgColumns(sortFields)
+	if err != nil {
+		return nil, err
+	}
+	return func(oi, oj org.Org) bool {
+		return lessByColumns(columns.columns, func(i int) (float64, float64) {
+			return columns.values[i](oi), columns.values[i](oj)
+		})
+	}, nil
+}
+
+// orgColumns are the sort fields of orgs with the functions to get their values, resolved once for all the rows
+type orgColumns struct {
+	columns []sortColumn
+	values  []func(o org.Org) float64
+}
+
+// getOrgColumns resolves the sort fields of orgs & the functions to get their values
+func getOrgColumns(sortFields []string) (orgColumns, error) {
+	var columns orgColumns
+	var err error
+	columns.columns, err = resolveSortColumns(sortFields, func(field string) (string, error) {
+		value, err := getOrgMetric(field)
+		columns.values = append(columns.values, value)
+		return field, err
+	})
+	return columns, err
+}
+
+// printOrgs print orgs in readable format
+func printOrgs(orgs []org.Org, limit uint32, sortFields []string) error {
+	columns, err := getOrgColumns(sortFields)
+	if err != nil {
+		return err
+	}
+
+	var str strings.Builder
+	for _, o := range orgs {
+		for i, column := range columns.columns {
+			fmt.Fprintf(&str, "%s:%s ", column.label, column.format(columns.values[i](o)))
+		}
+		fmt.Fprintf(&str, "Name:%s \n", o.Name)
+	}
+	fmt.Printf("Top %d Orgs by %v \n --- \n%s --- \n", limit, sortFields, str.String())
+	return nil
+}
@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"github.com/ameykpatil/github-data-analyzer/domain/persona"
+	"github.com/spf13/cobra"
+)
+
+// addPersonaFlags adds the flags to configure the thresholds of the personas to the command
+func addPersonaFlags(cmd *cobra.Command) {
+	cmd.Flags().Float64("coder-share", persona.DefaultThresholds.Coder, "minimum share of push events of a coder")
+	cmd.Flags().Float64("reviewer-share", persona.DefaultThresholds.Reviewer, "minimum share of pull request review events of a reviewer")
+	cmd.Flags().Float64("triager-share", persona.DefaultThresholds.Triager, "minimum share of issue & issue comment events of a triager")
+	cmd.Flags().Float64("maintainer-share", persona.DefaultThresholds.Maintainer, "minimum share of release, member & create events of a maintainer")
+	cmd.Flags().Float64("stargazer-share", persona.DefaultThresholds.Stargazer, "minimum share of watch & fork events of a stargazer")
+}
+
+// getPersonaThresholds returns the thresholds of the personas from the flags of the command
+func getPersonaThresholds(cmd *cobra.Command) (persona.Thresholds, error) {
+	var thresholds persona.Thresholds
+	for _, flag := range []struct {
+		name      string
+		threshold *float64
+	}{
+		{"coder-share", &thresholds.Coder},
+		{"reviewer-share", &thresholds.Reviewer},
+		{"triager-share", &thresholds.Triager},
+		{"maintainer-share", &thresholds.Maintainer},
+		{"stargazer-share", &thresholds.Stargazer},
+	} {
+		value, err := cmd.Flags().GetFloat64(flag.name)
+		if err != nil {
+			return persona.Thresholds{}, err
+		}
+		*flag.threshold = value
+	}
+	return thresholds, thresholds.Validate()
+}
@@ -0,0 +1,25 @@
+package cmd
+
+import (
+	"github.com/ameykpatil/github-data-analyzer/domain/topk"
+	"github.com/spf13/cobra"
+)
+
+// addRankFlags adds the flags to configure the ranking to the command
+func addRankFlags(cmd *cobra.Command) {
+	cmd.Flags().String("rank-mode", string(topk.Competition), "how tied entries are ranked, competition (1,2,2,4), dense (1,2,2,3) or ordinal (1,2,3,4)")
+	cmd.Flags().String("tie-breaker", string(topk.ByID), "order of tied entries, id or name")
+}
+
+// getRankOptions returns the ranking options from the flags of the command
+func getRankOptions(cmd *cobra.Command) (topk.Options, error) {
+	mode, err := cmd.Flags().GetString("rank-mode")
+	if err != nil {
+		return topk.Options{}, err
+	}
+	tieBreaker, err := cmd.Flags().GetString("tie-breaker")
+	if err != nil {
+		return topk.Options{}, err
+	}
+	return topk.ParseOptions(mode, tieBreaker)
+}
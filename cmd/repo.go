@@ -0,0 +1,208 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ameykpatil/github-data-analyzer/db"
+	"github.com/ameykpatil/github-data-analyzer/domain/repo"
+	"github.com/ameykpatil/github-data-analyzer/service"
+	"github.com/spf13/cobra"
+)
+
+// NewRepoCmd command to look at a single repo
+func NewRepoCmd() *cobra.Command {
+	repoCmd := &cobra.Command{
+		Use:   "repo",
+		Short: "Look at a single repo",
+	}
+
+	repoCmd.AddCommand(NewRepoShowCmd())
+
+	return repoCmd
+}
+
+// NewRepoShowCmd command to get the profile of a repo
+func NewRepoShowCmd() *cobra.Command {
+	showCmd := &cobra.Command{
+		Use:   "show <id|owner/name>",
+		Short: "Get the profile of a repo",
+		Args:  cobra.ExactArgs(1),
+		RunE:  showRepo,
+	}
+
+	showCmd.Flags().StringP("path", "p", "", "path of the directory where the data files are")
+	showCmd.Flags().Uint32P("limit", "l", 10, "number of contributors to return, 0 returns all")
+	showCmd.Flags().Float64("bus-factor-share", repo.DefaultBusFactorShare, "share of commits the bus factor users should account for")
+	showCmd.Flags().Int("min-commits", 0, "minimum number of commits for a repo to be ranked")
+
+	addFormatFlag(showCmd)
+
+	return showCmd
+}
+
+func showRepo(cmd *cobra.Command, args []string) error {
+	// get & verify flags
+	path, err := cmd.Flags().GetString("path")
+	if err != nil {
+		return err
+	}
+	limit, err := cmd.Flags().GetUint32("limit")
+	if err != nil {
+		return err
+	}
+	busFactorShare, err := cmd.Flags().GetFloat64("bus-factor-share")
+	if err != nil {
+		return err
+	}
+	if busFactorShare <= 0 || busFactorShare > 1 {
+		return errors.New("bus factor share should be greater than 0 & at most 1")
+	}
+	minCommits, err := cmd.Flags().GetInt("min-commits")
+	if err != nil {
+		return err
+	}
+	format, err := getFormat(cmd)
+	if err != nil {
+		return err
+	}
+
+	// initialise dependencies
+	dataStore, err := db.NewDataStore(path)
+	if err != nil {
+		return err
+	}
+	eventHandler := service.NewEventHandler(dataStore)
+	repoAnalyzer := repo.NewAnalyzer(*eventHandler, repo.Options{BusFactorShare: busFactorShare, MinCommits: minCommits})
+
+	// get the repo with its top contributors
+	r, ok := repoAnalyzer.GetRepo(args[0])
+	if !ok {
+		return errors.New("repo not found " + args[0])
+	}
+	contributors := repoAnalyzer.GetTopContributors(r.ID, limit)
+
+	// get the rank under the built-in sorts & each event type of the repo
+	sortFields := []string{"Commits", "DistinctCommits", "BusFactor", "Gini", "Herfindahl"}
+	eventTypes := sortedEventTypes(r.EventTypeCount)
+	if _, ok := r.EventTypeCount["WatchEvent"]; !ok {
+		eventTypes = append(eventTypes, "WatchEvent")
+	}
+	sortFields = append(sortFields, eventTypes...)
+	ranks := make([]repo.Rank, 0, len(sortFields))
+	for _, sortField := range sortFields {
+		fn, err := getRepoSortFunction([]string{sortField})
+		if err != nil {
+			return err
+		}
+		ranks = append(ranks, repoAnalyzer.GetRank(r, fn))
+	}
+
+	// print the result in given format
+	if format == "json" {
+		return printRepoProfileJSON(r, contributors, sortFields, ranks)
+	}
+	printRepoProfile(r, contributors, sortFields, ranks)
+
+	return nil
+}
+
+// printRepoProfile print the profile of the repo in readable format
+func printRepoProfile(r repo.Repo, contributors []repo.Contributor, sortFields []string, ranks []repo.Rank) {
+	var str strings.Builder
+	fmt.Fprintf(&str, "ID:%s Name:%s Owner:%s \n", r.ID, r.Name, r.Owner)
+	fmt.Fprintf(&str, "Commits:%d DistinctCommits:%d Contributors:%d \n", r.CommitCount, r.DistinctCommitCount, r.ContributorCount)
+	fmt.Fprintf(&str, "BusFactor:%d Gini:%.4f Herfindahl:%.4f \n", r.BusFactor, r.Gini, r.Herfindahl)
+	for _, eventType := range sortedEventTypes(r.EventTypeCount) {
+		fmt.Fprintf(&str, "%s:%d Actors:%d \n", eventType, r.EventTypeCount[eventType], r.EventTypeActorCount[eventType])
+	}
+	fmt.Printf("Repo \n --- \n%s --- \n", str.String())
+
+	events := eventCount(r)
+	str.Reset()
+	for _, contributor := range contributors {
+		fmt.Fprintf(&str, "Commits:%d CommitShare:%.2f%% Events:%d EventShare:%.2f%% ID:%s Username:%s \n",
+			contributor.CommitCount, share(contributor.CommitCount, r.CommitCount), contributor.EventCount,
+			share(contributor.EventCount, events), contributor.ID, contributor.Username)
+	}
+	fmt.Printf("Top Contributors \n --- \n%s --- \n", str.String())
+
+	str.Reset()
+	for i, rank := range ranks {
+		fmt.Fprintf(&str, "Rank:%d/%d Percentile:%.2f Sort:%s \n", rank.Rank, rank.Total, rank.Percentile, sortFields[i])
+	}
+	fmt.Printf("Ranks among repos \n --- \n%s --- \n", str.String())
+}
+
+// repoProfileJSON is the profile of a repo in json format
+type repoProfileJSON struct {
+	ID              string                 `json:"id"`
+	Name            string                 `json:"name"`
+	Owner           string                 `json:"owner"`
+	Commits         int                    `json:"commits"`
+	DistinctCommits int                    `json:"distinctCommits"`
+	Contributors    int                    `json:"contributors"`
+	BusFactor       int                    `json:"busFactor"`
+	Gini            float64                `json:"gini"`
+	Herfindahl      float64                `json:"herfindahl"`
+	EventTypes      map[string]int         `json:"eventTypes"`
+	EventTypeActors map[string]int         `json:"eventTypeActors"`
+	TopContributors []contributorShareJSON `json:"topContributors"`
+	Ranks           []rankJSON             `json:"ranks"`
+}
+
+// contributorShareJSON is a contributor of a repo with its shares (percentages) of the commits & events in json format
+type contributorShareJSON struct {
+	contributorJSON
+	CommitShare float64 `json:"commitShare"`
+	EventShare  float64 `json:"eventShare"`
+}
+
+// printRepoProfileJSON print the profile of the repo in json format
+func printRepoProfileJSON(r repo.Repo, contributors []repo.Contributor, sortFields []string, ranks []repo.Rank) error {
+	out := repoProfileJSON{
+		ID:              r.ID,
+		Name:            r.Name,
+		Owner:           r.Owner,
+		Commits:         r.CommitCount,
+		DistinctCommits: r.DistinctCommitCount,
+		Contributors:    r.ContributorCount,
+		BusFactor:       r.BusFactor,
+		Gini:            r.Gini,
+		Herfindahl:      r.Herfindahl,
+		EventTypes:      r.EventTypeCount,
+		EventTypeActors: r.EventTypeActorCount,
+		TopContributors: make([]contributorShareJSON, 0, len(contributors)),
+		Ranks:           make([]rankJSON, 0, len(ranks)),
+	}
+	events := eventCount(r)
+	for _, contributor := range contributors {
+		out.TopContributors = append(out.TopContributors, contributorShareJSON{
+			contributorJSON: contributorJSON{ID: contributor.ID, Username: contributor.Username, Commits: contributor.CommitCount, Events: contributor.EventCount},
+			CommitShare:     share(contributor.CommitCount, r.CommitCount),
+			EventShare:      share(contributor.EventCount, events),
+		})
+	}
+	for i, rank := range ranks {
+		out.Ranks = append(out.Ranks, rankJSON{Sort: []string{sortFields[i]}, Rank: rank.Rank, Total: rank.Total, Percentile: rank.Percentile})
+	}
+	return printJSON(out)
+}
+
+// eventCount returns the number of events of the repo
+func eventCount(r repo.Repo) int {
+	events := 0
+	for _, count := range r.EventTypeCount {
+		events += count
+	}
+	return events
+}
+
+// share returns the percentage of count in total
+func share(count, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return 100 * float64(count) / float64(total)
+}
@@ -20,8 +20,18 @@ func NewReposCmd() *cobra.Command {
 	}
 
 	reposCmd.Flags().StringP("path", "p", "", "path of the directory where the data files are")
-	reposCmd.Flags().Uint32P("limit", "l", 10, "number of users to return")
-	reposCmd.Flags().StringP("sort", "s", "commits", "field to sort by")
+	reposCmd.Flags().Uint32P("limit", "l", 10, "number of repos to return, 0 returns all")
+	reposCmd.Flags().Uint32P("offset", "o", 0, "number of top repos to skip")
+	reposCmd.Flags().StringSliceP("sort", "s", []string{"commits"}, "fields to sort by, descending unless prefixed with + or suffixed with :asc")
+	reposCmd.Flags().Float64("bus-factor-share", repo.DefaultBusFactorShare, "share of commits the bus factor users should account for")
+	reposCmd.Flags().Int("min-commits", 0, "minimum number of commits for a repo to be ranked")
+	reposCmd.Flags().Uint32("per-repo-contributors", 0, "number of top contributors to list for each of the top repos, 0 lists none")
+
+	addFormatFlag(reposCmd)
+
+	addRankFlags(reposCmd)
+	addInfluenceFlags(reposCmd)
+	addApproximateFlags(reposCmd)
 
 	return reposCmd
 }
@@ -36,26 +46,56 @@ func getTopRepos(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
-	sortField, err := cmd.Flags().GetString("sort")
+	offset, err := cmd.Flags().GetUint32("offset")
 	if err != nil {
 		return err
 	}
-
-	// create sort function based on sort field
-	var fn func(ri, rj repo.Repo) bool
-	switch sortField {
-	case "Commits":
-		fn = func(ri, rj repo.Repo) bool {
-			return ri.CommitCount > rj.CommitCount
-		}
-	default:
-		if strings.Contains(sortField, "Event") {
-			fn = func(ri, rj repo.Repo) bool {
-				return ri.EventTypeCount[sortField] > rj.EventTypeCount[sortField]
-			}
-		} else {
-			return errors.New("invalid sort field " + sortField)
+	sortFields, err := cmd.Flags().GetStringSlice("sort")
+	if err != nil {
+		return err
+	}
+	busFactorShare, err := cmd.Flags().GetFloat64("bus-factor-share")
+	if err != nil {
+		return err
+	}
+	if busFactorShare <= 0 || busFactorShare > 1 {
+		return errors.New("bus factor share should be greater than 0 & at most 1")
+	}
+	minCommits, err := cmd.Flags().GetInt("min-commits")
+	if err != nil {
+		return err
+	}
+	perRepoContributors, err := cmd.Flags().GetUint32("per-repo-contributors")
+	if err != nil {
+		return err
+	}
+	format, err := getFormat(cmd)
+	if err != nil {
+		return err
+	}
+	rankOptions, err := getRankOptions(cmd)
+	if err != nil {
+		return err
+	}
+	influenceOptions, err := getInfluenceOptions(cmd)
+	if err != nil {
+		return err
+	}
+	approximate, err := cmd.Flags().GetBool("approximate")
+	if err != nil {
+		return err
+	}
+	if approximate {
+		if perRepoContributors > 0 || format != "text" {
+			return errors.New("approximate mode supports neither per-repo-contributors nor json format")
 		}
+		return getApproximateTopRepos(cmd, path, limit, offset, sortFields)
+	}
+
+	// create custom sort function
+	fn, err := getRepoSortFunction(sortFields)
+	if err != nil {
+		return err
 	}
 
 	// initialise dependencies
@@ -64,29 +104,112 @@ func getTopRepos(cmd *cobra.Command, args []string) error {
 		return err
 	}
 	eventHandler := service.NewEventHandler(dataStore)
-	repoAnalyzer := repo.NewAnalyzer(*eventHandler)
+	repoAnalyzer := repo.NewAnalyzer(*eventHandler, repo.Options{BusFactorShare: busFactorShare, MinCommits: minCommits})
+	setInfluence(eventHandler, influenceOptions, sortFields, nil, repoAnalyzer)
 
 	// get the top repos
-	repos := repoAnalyzer.GetTopRepos(limit, fn)
+	repos := repoAnalyzer.GetRankedRepos(limit, offset, fn, rankOptions)
 
-	// print the result in readable format
-	printRepos(repos, limit, []string{sortField})
+	// get the top contributors of all the top repos at once
+	var contributorsPerRepo map[string][]repo.Contributor
+	if perRepoContributors > 0 {
+		repoIDs := make([]string, 0, len(repos))
+		for _, r := range repos {
+			repoIDs = append(repoIDs, r.ID)
+		}
+		contributorsPerRepo = repoAnalyzer.GetTopContributorsPerRepo(repoIDs, perRepoContributors)
+	}
+
+	// print the result in given format
+	if format == "json" {
+		return printReposJSON(repos, sortFields, contributorsPerRepo)
+	}
+	if err := printRepos(repos, limit, sortFields, contributorsPerRepo); err != nil {
+		return err
+	}
+	printSharedCommits(eventHandler)
 
 	return nil
 }
 
-// printRepos print repos in readable format
-func printRepos(repos []repo.Repo, limit uint32, sortFields []string) {
+// getApproximateTopRepos gets & prints the estimated top repos by the sort field
+func getApproximateTopRepos(cmd *cobra.Command, path string, limit, offset uint32, sortFields []string) error {
+	metric, err := getApproximateMetric(cmd, sortFields, repoMetricNames())
+	if err != nil {
+		return err
+	}
+	summary, err := getSummary(cmd, path)
+	if err != nil {
+		return err
+	}
+	printEstimates("Repos", summary.GetTopRepos(metric, limit, offset), limit, metric, summary.GetDistinctRepos(""))
+	return nil
+}
+
+// getRepoSortFunction creates multilevel sort function based on sortFields
+func getRepoSortFunction(sortFields []string) (func(ri, rj repo.Repo) bool, error) {
+	columns, err := getRepoColumns(sortFields)
+	if err != nil {
+		return nil, err
+	}
+	return func(ri, rj repo.Repo) bool {
+		return lessByColumns(columns.columns, func(i int) (float64, float64) {
+			return columns.values[i](ri), columns.values[i](rj)
+		})
+	}, nil
+}
+
+// getRepoValue returns the label & the function to get the value of the sort field (metric or score) of a repo
+func getRepoValue(field string) (string, func(r repo.Repo) float64, error) {
+	if isScoreField(field) {
+		return getRepoScore(field)
+	}
+	value, err := getRepoMetric(field)
+	return field, value, err
+}
+
+// repoColumns are the sort fields of repos with the functions to get their values, resolved once for all the rows
+type repoColumns struct {
+	columns []sortColumn
+	values  []func(r repo.Repo) float64
+}
+
+// getRepoColumns resolves the sort fields of repos & the functions to get their values
+func getRepoColumns(sortFields []string) (repoColumns, error) {
+	var columns repoColumns
+	var err error
+	columns.columns, err = resolveSortColumns(sortFields, func(field string) (string, error) {
+		label, value, err := getRepoValue(field)
+		columns.values = append(columns.values, value)
+		return label, err
+	})
+	return columns, err
+}
+
+// printRepos print repos in readable format along with their top contributors (if any) indented below each repo
+// the rank is printed first & Tie tells if the repo has the same sort key as another one
+func printRepos(repos []repo.RankedRepo, limit uint32, sortFields []string, contributorsPerRepo map[string][]repo.Contributor) error {
+	columns, err := getRepoColumns(sortFields)
+	if err != nil {
+		return err
+	}
+
 	var str strings.Builder
-	for _, repo := range repos {
-		for _, sortField := range sortFields {
-			if sortField == "Commits" {
-				fmt.Fprintf(&str, "%s:%d ", sortField, repo.CommitCount)
-			} else if strings.Contains(sortField, "Event") {
-				fmt.Fprintf(&str, "%s:%d ", sortField, repo.EventTypeCount[sortField])
-			}
+	for _, r := range repos {
+		fmt.Fprintf(&str, "Rank:%d Tie:%t ", r.Rank, r.Tie)
+		for i, column := range columns.columns {
+			fmt.Fprintf(&str, "%s:%s ", column.label, column.format(columns.values[i](r.Repo)))
+		}
+		fmt.Fprintf(&str, "ID:%s Name:%s \n", r.ID, r.Name)
+		for _, contributor := range contributorsPerRepo[r.ID] {
+			fmt.Fprintf(&str, "    Commits:%d Events:%d ID:%s Username:%s \n", contributor.CommitCount, contributor.EventCount, contributor.ID, contributor.Username)
 		}
-		fmt.Fprintf(&str, "ID:%s Name:%s \n", repo.ID, repo.Name)
 	}
 	fmt.Printf("Top %d Repos by %v \n --- \n%s --- \n", limit, sortFields, str.String())
+	return nil
+}
+
+// printSharedCommits print the number of commit shas pushed to more than one repo
+func printSharedCommits(eventHandler *service.EventHandler) {
+	fmt.Printf("Commits pushed to more than one repo: %d \n", eventHandler.CountSharedCommits())
 }
@@ -14,6 +14,20 @@ func NewRootCmd(version string) *cobra.Command {
 	cmd.AddCommand(NewAllCmd())
 	cmd.AddCommand(NewUsersCmd())
 	cmd.AddCommand(NewReposCmd())
+	cmd.AddCommand(NewOrgsCmd())
+	cmd.AddCommand(NewTypesCmd())
+	cmd.AddCommand(NewMessagesCmd())
+	cmd.AddCommand(NewGraphCmd())
+	cmd.AddCommand(NewSimilarCmd())
+	cmd.AddCommand(NewUserCmd())
+	cmd.AddCommand(NewRepoCmd())
+	cmd.AddCommand(NewDistributionCmd())
+	cmd.AddCommand(NewAnomaliesCmd())
+	cmd.AddCommand(NewDiffCmd())
+	cmd.AddCommand(NewSegmentsCmd())
+	cmd.AddCommand(NewClusterCmd())
+	cmd.AddCommand(NewCommunitiesCmd())
+	cmd.AddCommand(NewSequencesCmd())
 
 	return cmd
 }
@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"strings"
+
+	"github.com/ameykpatil/github-data-analyzer/domain/repo"
+	"github.com/ameykpatil/github-data-analyzer/domain/score"
+	"github.com/ameykpatil/github-data-analyzer/domain/user"
+)
+
+// isScoreField reports whether the sort field is a score expression e.g. score=3*PullRequestEvent+Commits
+func isScoreField(field string) bool {
+	return strings.Contains(field, "=")
+}
+
+// parseScoreField splits the score field into the label & the parsed expression
+func parseScoreField(field string) (string, *score.Expression, error) {
+	i := strings.Index(field, "=")
+	expression, err := score.Parse(field[i+1:])
+	if err != nil {
+		return "", nil, err
+	}
+	return strings.TrimSpace(field[:i]), expression, nil
+}
+
+// getUserScore returns the label & the function to compute the score of a user for the score field
+// the variables of the expression are metrics or their aliases
+func getUserScore(field string) (string, func(u user.User) float64, error) {
+	label, expression, err := parseScoreField(field)
+	if err != nil {
+		return "", nil, err
+	}
+	metrics := make(map[string]func(u user.User) float64)
+	for _, name := range expression.Variables() {
+		if metrics[name], err = getUserMetric(name); err != nil {
+			return "", nil, err
+		}
+	}
+	return label, func(u user.User) float64 {
+		return expression.Evaluate(func(name string) float64 {
+			return metrics[name](u)
+		})
+	}, nil
+}
+
+// getRepoScore returns the label & the function to compute the score of a repo for the score field
+// the variables of the expression are metrics or their aliases
+func getRepoScore(field string) (string, func(r repo.Repo) float64, error) {
+	label, expression, err := parseScoreField(field)
+	if err != nil {
+		return "", nil, err
+	}
+	metrics := make(map[string]func(r repo.Repo) float64)
+	for _, name := range expression.Variables() {
+		if metrics[name], err = getRepoMetric(name); err != nil {
+			return "", nil, err
+		}
+	}
+	return label, func(r repo.Repo) float64 {
+		return expression.Evaluate(func(name string) float64 {
+			return metrics[name](r)
+		})
+	}, nil
+}
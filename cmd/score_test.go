@@ -0,0 +1,28 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/ameykpatil/github-data-analyzer/domain/repo"
+	"github.com/ameykpatil/github-data-analyzer/domain/user"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetScore(t *testing.T) {
+	u := user.User{CommitCount: 3, EventTypeCount: map[string]int{"PullRequestEvent": 2}}
+	r := repo.Repo{CommitCount: 4, EventTypeCount: map[string]int{"WatchEvent": 5}}
+
+	// the variables can be aliases
+	label, value, err := getUserScore("score=3*prs+commits")
+	assert.NoError(t, err)
+	assert.Equal(t, "score", label)
+	assert.Equal(t, 9.0, value(u))
+
+	label, repoValue, err := getRepoScore("popularity = stars + 2*Commits")
+	assert.NoError(t, err)
+	assert.Equal(t, "popularity", label)
+	assert.Equal(t, 13.0, repoValue(r))
+
+	_, _, err = getUserScore("score=3*prz")
+	assert.EqualError(t, err, "unknown field prz, did you mean prs?")
+}
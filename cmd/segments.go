@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ameykpatil/github-data-analyzer/db"
+	"github.com/ameykpatil/github-data-analyzer/domain/persona"
+	"github.com/ameykpatil/github-data-analyzer/domain/user"
+	"github.com/ameykpatil/github-data-analyzer/service"
+	"github.com/spf13/cobra"
+)
+
+// NewSegmentsCmd command to get the number of users of each persona
+func NewSegmentsCmd() *cobra.Command {
+	segmentsCmd := &cobra.Command{
+		Use:   "segments",
+		Short: "Get the number of users of each persona",
+		RunE:  getSegments,
+	}
+
+	segmentsCmd.Flags().StringP("path", "p", "", "path of the directory where the data files are")
+
+	addPersonaFlags(segmentsCmd)
+
+	return segmentsCmd
+}
+
+func getSegments(cmd *cobra.Command, args []string) error {
+	// get & verify flags
+	path, err := cmd.Flags().GetString("path")
+	if err != nil {
+		return err
+	}
+	thresholds, err := getPersonaThresholds(cmd)
+	if err != nil {
+		return err
+	}
+
+	// initialise dependencies
+	dataStore, err := db.NewDataStore(path)
+	if err != nil {
+		return err
+	}
+	eventHandler := service.NewEventHandler(dataStore)
+	userAnalyzer := user.NewAnalyzer(*eventHandler)
+
+	// classify the users into the personas
+	segments := persona.GetSegments(userAnalyzer.GetUsers(), thresholds)
+
+	// print the result in readable format
+	printSegments(segments)
+
+	return nil
+}
+
+// printSegments print the segments of the personas in readable format
+func printSegments(segments []persona.Segment) {
+	var str strings.Builder
+	for _, segment := range segments {
+		fmt.Fprintf(&str, "Persona:%s Users:%d Share:%.2f%% Events:%d Commits:%d \n",
+			segment.Persona, segment.Count, segment.Share, segment.Events, segment.Commits)
+	}
+	fmt.Printf("User Segments \n --- \n%s --- \n", str.String())
+}
@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ameykpatil/github-data-analyzer/db"
+	"github.com/ameykpatil/github-data-analyzer/domain/sequence"
+	"github.com/ameykpatil/github-data-analyzer/service"
+	"github.com/spf13/cobra"
+)
+
+// NewSequencesCmd command to mine the transitions & common patterns of the event types of the users
+func NewSequencesCmd() *cobra.Command {
+	sequencesCmd := &cobra.Command{
+		Use:   "sequences",
+		Short: "Mine the transitions & common patterns of the event types of the users",
+		RunE:  getSequences,
+	}
+
+	sequencesCmd.Flags().StringP("path", "p", "", "path of the directory where the data files are")
+	sequencesCmd.Flags().IntP("steps", "n", 3, "number of steps of the patterns")
+	sequencesCmd.Flags().Bool("by-repo", false, "split the events of a user into a sequence per repo")
+	sequencesCmd.Flags().String("repo", "", "id or name of the repo whose events are analyzed, default is all")
+	sequencesCmd.Flags().Bool("repeats", false, "keep the consecutive events of the same type")
+	sequencesCmd.Flags().Uint32P("limit", "l", 10, "number of transitions & patterns to return, 0 returns all")
+
+	return sequencesCmd
+}
+
+func getSequences(cmd *cobra.Command, args []string) error {
+	// get & verify the flags
+	path, err := cmd.Flags().GetString("path")
+	if err != nil {
+		return err
+	}
+	steps, err := cmd.Flags().GetInt("steps")
+	if err != nil {
+		return err
+	}
+	if steps < 2 {
+		return errors.New("steps should be at least 2")
+	}
+	byRepo, err := cmd.Flags().GetBool("by-repo")
+	if err != nil {
+		return err
+	}
+	repoID, err := cmd.Flags().GetString("repo")
+	if err != nil {
+		return err
+	}
+	repeats, err := cmd.Flags().GetBool("repeats")
+	if err != nil {
+		return err
+	}
+	limit, err := cmd.Flags().GetUint32("limit")
+	if err != nil {
+		return err
+	}
+
+	// initialise dependencies
+	dataStore, err := db.NewDataStore(path)
+	if err != nil {
+		return err
+	}
+	eventHandler := service.NewEventHandler(dataStore)
+	sequenceAnalyzer := sequence.NewAnalyzer(*eventHandler)
+
+	// mine the sequences
+	sequences := sequenceAnalyzer.GetSequences(sequence.Options{ByRepo: byRepo, Repo: repoID, Repeats: repeats})
+	if repoID != "" && len(sequences) == 0 {
+		return errors.New("no events found for repo " + repoID)
+	}
+	transitions := sequence.GetTransitions(sequences, limit)
+	patterns := sequence.GetPatterns(sequences, steps, limit)
+
+	// print the result in readable format
+	printSequences(len(sequences), transitions, patterns)
+
+	return nil
+}
+
+// printSequences print the transitions & the patterns in readable format, event types without the Event suffix
+func printSequences(count int, transitions []sequence.Transition, patterns []sequence.Pattern) {
+	var str strings.Builder
+	for _, transition := range transitions {
+		fmt.Fprintf(&str, "%s -> %s Count:%d Probability:%.2f%% \n",
+			shortEventType(transition.From), shortEventType(transition.To), transition.Count, 100*transition.Probability)
+	}
+	fmt.Printf("Transitions of %d sequences \n --- \n%s --- \n", count, str.String())
+
+	str.Reset()
+	for _, pattern := range patterns {
+		steps := make([]string, 0, len(pattern.Steps))
+		for _, step := range pattern.Steps {
+			steps = append(steps, shortEventType(step))
+		}
+		fmt.Fprintf(&str, "%s Count:%d Sequences:%d \n", strings.Join(steps, " -> "), pattern.Count, pattern.Sequences)
+	}
+	fmt.Printf("Patterns \n --- \n%s --- \n", str.String())
+}
+
+// shortEventType returns the event type without the Event suffix e.g. Push for PushEvent
+func shortEventType(eventType string) string {
+	return strings.TrimSuffix(eventType, "Event")
+}
@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ameykpatil/github-data-analyzer/db"
+	"github.com/ameykpatil/github-data-analyzer/domain/repo"
+	"github.com/ameykpatil/github-data-analyzer/domain/similarity"
+	"github.com/ameykpatil/github-data-analyzer/service"
+	"github.com/spf13/cobra"
+)
+
+// NewSimilarCmd command to get repos similar to the given repo
+func NewSimilarCmd() *cobra.Command {
+	similarCmd := &cobra.Command{
+		Use:   "similar <id|owner/name>",
+		Short: "Get the repos sharing most contributors with the given repo",
+		Args:  cobra.ExactArgs(1),
+		RunE:  getSimilarRepos,
+	}
+
+	similarCmd.Flags().StringP("path", "p", "", "path of the directory where the data files are")
+	similarCmd.Flags().Uint32P("limit", "l", 10, "number of repos to return, 0 returns all")
+	similarCmd.Flags().Uint32P("offset", "o", 0, "number of top repos to skip")
+	similarCmd.Flags().StringP("sort", "s", "Jaccard", "field to sort by Jaccard, Cosine or Shared")
+
+	return similarCmd
+}
+
+func getSimilarRepos(cmd *cobra.Command, args []string) error {
+	// get & verify the flags
+	path, err := cmd.Flags().GetString("path")
+	if err != nil {
+		return err
+	}
+	limit, err := cmd.Flags().GetUint32("limit")
+	if err != nil {
+		return err
+	}
+	offset, err := cmd.Flags().GetUint32("offset")
+	if err != nil {
+		return err
+	}
+	sortField, err := cmd.Flags().GetString("sort")
+	if err != nil {
+		return err
+	}
+
+	// create sort function based on sort field
+	var fn func(si, sj similarity.Similarity) bool
+	switch sortField {
+	case "Jaccard":
+		fn = func(si, sj similarity.Similarity) bool {
+			return si.Jaccard > sj.Jaccard
+		}
+	case "Cosine":
+		fn = func(si, sj similarity.Similarity) bool {
+			return si.Cosine > sj.Cosine
+		}
+	case "Shared":
+		fn = func(si, sj similarity.Similarity) bool {
+			return si.SharedContributors > sj.SharedContributors
+		}
+	default:
+		return errors.New("invalid sort field " + sortField)
+	}
+
+	// initialise dependencies
+	dataStore, err := db.NewDataStore(path)
+	if err != nil {
+		return err
+	}
+	eventHandler := service.NewEventHandler(dataStore)
+	repoAnalyzer := repo.NewAnalyzer(*eventHandler, repo.Options{})
+	similarityAnalyzer := similarity.NewAnalyzer(repoAnalyzer)
+
+	// get the similar repos
+	target, similarities, err := similarityAnalyzer.GetSimilarRepos(args[0], limit, offset, fn)
+	if err != nil {
+		return err
+	}
+
+	// print the result in readable format
+	printSimilarities(target, similarities, limit, sortField)
+
+	return nil
+}
+
+// printSimilarities print similar repos in readable format
+func printSimilarities(target repo.Repo, similarities []similarity.Similarity, limit uint32, sortField string) {
+	var str strings.Builder
+	for _, s := range similarities {
+		fmt.Fprintf(&str, "Jaccard:%.4f Cosine:%.4f Shared:%d ID:%s Name:%s \n",
+			s.Jaccard, s.Cosine, s.SharedContributors, s.Repo.ID, s.Repo.Name)
+	}
+	fmt.Printf("Top %d Repos similar to ID:%s Name:%s by %s \n --- \n%s --- \n", limit, target.ID, target.Name, sortField, str.String())
+}
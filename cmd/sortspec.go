@@ -0,0 +1,166 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// sortAliases are the short names of the sort fields, they are case insensitive
+var sortAliases = map[string]string{
+	"commits":  "Commits",
+	"prs":      "PullRequestEvent",
+	"stars":    "WatchEvent",
+	"forks":    "ForkEvent",
+	"pushes":   "PushEvent",
+	"issues":   "IssuesEvent",
+	"comments": "IssueCommentEvent",
+	"releases": "ReleaseEvent",
+}
+
+// sortedAliases returns the aliases of the sort fields in a fixed order
+func sortedAliases() []string {
+	aliases := make([]string, 0, len(sortAliases))
+	for alias := range sortAliases {
+		aliases = append(aliases, alias)
+	}
+	sort.Strings(aliases)
+	return aliases
+}
+
+// sortKey is a field of a sort specification with its direction, the default direction is descending
+type sortKey struct {
+	field     string
+	ascending bool
+}
+
+// parseSortKey parses the sort field with an optional - (descending) or + (ascending) prefix
+// or :desc or :asc suffix & resolves the alias of the field, also after the scope e.g. External:prs
+func parseSortKey(spec string) sortKey {
+	key := sortKey{field: strings.TrimSpace(spec)}
+	switch {
+	case strings.HasSuffix(key.field, ":asc"):
+		key.field, key.ascending = strings.TrimSuffix(key.field, ":asc"), true
+	case strings.HasSuffix(key.field, ":desc"):
+		key.field = strings.TrimSuffix(key.field, ":desc")
+	case strings.HasPrefix(key.field, "+"):
+		key.field, key.ascending = key.field[1:], true
+	case strings.HasPrefix(key.field, "-"):
+		key.field = key.field[1:]
+	}
+
+	if isScoreField(key.field) {
+		return key
+	}
+	key.field = resolveAlias(key.field)
+	return key
+}
+
+// sortColumn is a resolved sort field of users, repos or orgs: its label, whether it is a score & its direction
+type sortColumn struct {
+	label     string
+	score     bool
+	ascending bool
+}
+
+// resolveSortColumns parses the sort fields & resolves each of them with resolve, which returns the label of the field
+func resolveSortColumns(sortFields []string, resolve func(field string) (string, error)) ([]sortColumn, error) {
+	columns := make([]sortColumn, 0, len(sortFields))
+	for _, sortField := range sortFields {
+		key := parseSortKey(sortField)
+		label, err := resolve(key.field)
+		if err != nil {
+			return nil, err
+		}
+		columns = append(columns, sortColumn{label: label, score: isScoreField(key.field), ascending: key.ascending})
+	}
+	return columns, nil
+}
+
+// lessByColumns compares two items column by column, values returns the values of both the items in the given column
+// items equal in all the columns are not less than each other, the tie is broken by the analyzer
+func lessByColumns(columns []sortColumn, values func(column int) (float64, float64)) bool {
+	for i, column := range columns {
+		si, sj := values(i)
+		if si == sj {
+			continue
+		}
+		return (si > sj) != column.ascending
+	}
+	return false
+}
+
+// format formats the value of the column, the scores are fractional & the metrics are formatted as counts
+func (c sortColumn) format(value float64) string {
+	if c.score {
+		return fmt.Sprintf("%.6g", value)
+	}
+	return formatMetric(value)
+}
+
+// resolveAlias returns the field the alias stands for, also after the scope e.g. External:prs, or the field itself
+func resolveAlias(field string) string {
+	scope, name := splitScope(field)
+	alias, ok := sortAliases[strings.ToLower(name)]
+	if !ok {
+		return field
+	}
+	if scope != "" {
+		return scope + ":" + alias
+	}
+	return alias
+}
+
+// unknownFieldError returns the error for an unknown field, suggesting the closest candidate if any
+// the aliases of the candidates are candidates too
+func unknownFieldError(field string, candidates []string) error {
+	for _, alias := range sortedAliases() {
+		if containsString(candidates, sortAliases[alias]) {
+			candidates = append(candidates, alias)
+		}
+	}
+	if suggestion := suggest(field, candidates); suggestion != "" {
+		return fmt.Errorf("unknown field %s, did you mean %s?", field, suggestion)
+	}
+	return errors.New("unknown field " + field)
+}
+
+// suggest returns the candidate closest to the name by edit distance (case insensitive) or "" if none is close enough
+func suggest(name string, candidates []string) string {
+	best, bestDistance := "", len(name)/3+2
+	for _, candidate := range candidates {
+		distance := editDistance(strings.ToLower(name), strings.ToLower(candidate))
+		if distance < bestDistance {
+			best, bestDistance = candidate, distance
+		}
+	}
+	return best
+}
+
+// editDistance returns the Levenshtein distance between a & b
+func editDistance(a, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = previous[j-1] + cost
+			if previous[j]+1 < current[j] {
+				current[j] = previous[j] + 1
+			}
+			if current[j-1]+1 < current[j] {
+				current[j] = current[j-1] + 1
+			}
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ameykpatil/github-data-analyzer/db"
+	"github.com/ameykpatil/github-data-analyzer/domain/eventtype"
+	"github.com/ameykpatil/github-data-analyzer/service"
+	"github.com/spf13/cobra"
+)
+
+// NewTypesCmd command to get the event type distribution
+func NewTypesCmd() *cobra.Command {
+	typesCmd := &cobra.Command{
+		Use:   "types",
+		Short: "Get the distribution of event types",
+		RunE:  getEventTypes,
+	}
+
+	typesCmd.Flags().StringP("path", "p", "", "path of the directory where the data files are")
+	typesCmd.Flags().StringP("sort", "s", "Count", "field to sort event types by")
+	typesCmd.Flags().StringP("by", "b", "", "breakdown the distribution by owner or user")
+	typesCmd.Flags().Uint32P("limit", "l", 10, "number of owners or users with most events to return, 0 returns all")
+	typesCmd.Flags().Uint32P("offset", "o", 0, "number of owners or users with most events to skip")
+
+	return typesCmd
+}
+
+func getEventTypes(cmd *cobra.Command, args []string) error {
+	// get & verify the flags
+	path, err := cmd.Flags().GetString("path")
+	if err != nil {
+		return err
+	}
+	sortField, err := cmd.Flags().GetString("sort")
+	if err != nil {
+		return err
+	}
+	by, err := cmd.Flags().GetString("by")
+	if err != nil {
+		return err
+	}
+	limit, err := cmd.Flags().GetUint32("limit")
+	if err != nil {
+		return err
+	}
+	offset, err := cmd.Flags().GetUint32("offset")
+	if err != nil {
+		return err
+	}
+
+	// create sort function based on sort field
+	var fn func(ti, tj eventtype.EventType) bool
+	switch sortField {
+	case "Count":
+		fn = func(ti, tj eventtype.EventType) bool {
+			return ti.Count > tj.Count
+		}
+	case "Share":
+		fn = func(ti, tj eventtype.EventType) bool {
+			return ti.Share > tj.Share
+		}
+	case "Actors":
+		fn = func(ti, tj eventtype.EventType) bool {
+			return ti.ActorCount > tj.ActorCount
+		}
+	case "Repos":
+		fn = func(ti, tj eventtype.EventType) bool {
+			return ti.RepoCount > tj.RepoCount
+		}
+	default:
+		return errors.New("invalid sort field " + sortField)
+	}
+
+	// create the key function based on breakdown
+	var key eventtype.KeyFunc
+	switch by {
+	case "":
+	case "owner":
+		key = eventtype.ByOwner
+	case "user":
+		key = eventtype.ByUser
+	default:
+		return errors.New("invalid breakdown " + by)
+	}
+
+	// initialise dependencies
+	dataStore, err := db.NewDataStore(path)
+	if err != nil {
+		return err
+	}
+	eventHandler := service.NewEventHandler(dataStore)
+	eventTypeAnalyzer := eventtype.NewAnalyzer(*eventHandler)
+
+	// get the distribution & print the result in readable format
+	if key == nil {
+		printEventTypes(eventTypeAnalyzer.GetEventTypes(fn), sortField)
+		return nil
+	}
+	for _, group := range eventTypeAnalyzer.GetGroups(key, limit, offset, fn) {
+		if group.Key == group.Name {
+			fmt.Printf("%s:%s Events:%d \n", strings.ToUpper(by[:1])+by[1:], group.Name, group.Count)
+		} else {
+			fmt.Printf("%s:%s ID:%s Events:%d \n", strings.ToUpper(by[:1])+by[1:], group.Name, group.Key, group.Count)
+		}
+		printEventTypes(group.EventTypes, sortField)
+	}
+
+	return nil
+}
+
+// printEventTypes print event types in readable format
+func printEventTypes(eventTypes []eventtype.EventType, sortField string) {
+	var str strings.Builder
+	for _, eventType := range eventTypes {
+		fmt.Fprintf(&str, "Type:%s Count:%d Share:%.2f%% Actors:%d Repos:%d \n",
+			eventType.Type, eventType.Count, eventType.Share*100, eventType.ActorCount, eventType.RepoCount)
+	}
+	fmt.Printf("Event Types by %s \n --- \n%s --- \n", sortField, str.String())
+}
@@ -0,0 +1,220 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ameykpatil/github-data-analyzer/db"
+	"github.com/ameykpatil/github-data-analyzer/domain/persona"
+	"github.com/ameykpatil/github-data-analyzer/domain/user"
+	"github.com/ameykpatil/github-data-analyzer/service"
+	"github.com/spf13/cobra"
+)
+
+// NewUserCmd command to look at a single user
+func NewUserCmd() *cobra.Command {
+	userCmd := &cobra.Command{
+		Use:   "user",
+		Short: "Look at a single user",
+	}
+
+	userCmd.AddCommand(NewUserShowCmd())
+
+	return userCmd
+}
+
+// NewUserShowCmd command to get the profile of a user
+func NewUserShowCmd() *cobra.Command {
+	showCmd := &cobra.Command{
+		Use:   "show <id|username>",
+		Short: "Get the profile of a user",
+		Args:  cobra.ExactArgs(1),
+		RunE:  showUser,
+	}
+
+	showCmd.Flags().StringP("path", "p", "", "path of the directory where the data files are")
+	showCmd.Flags().Uint32P("limit", "l", 10, "number of repos to return, 0 returns all")
+
+	addFormatFlag(showCmd)
+	addPersonaFlags(showCmd)
+
+	return showCmd
+}
+
+func showUser(cmd *cobra.Command, args []string) error {
+	// get & verify flags
+	path, err := cmd.Flags().GetString("path")
+	if err != nil {
+		return err
+	}
+	limit, err := cmd.Flags().GetUint32("limit")
+	if err != nil {
+		return err
+	}
+	format, err := getFormat(cmd)
+	if err != nil {
+		return err
+	}
+	thresholds, err := getPersonaThresholds(cmd)
+	if err != nil {
+		return err
+	}
+
+	// initialise dependencies
+	dataStore, err := db.NewDataStore(path)
+	if err != nil {
+		return err
+	}
+	eventHandler := service.NewEventHandler(dataStore)
+	userAnalyzer := user.NewAnalyzer(*eventHandler)
+
+	// get the user with its activity on the repos
+	u, ok := userAnalyzer.GetUser(args[0])
+	if !ok {
+		return errors.New("user not found " + args[0])
+	}
+	activities := userAnalyzer.GetRepoActivities(u.ID)
+	if limit > 0 && int(limit) < len(activities) {
+		activities = activities[:limit]
+	}
+
+	// get the rank under the common sorts i.e. the one used by all command, commits & each event type of the user
+	sorts := [][]string{{"PullRequestEvent", "Commits"}, {"Commits"}, {"DistinctCommits"}}
+	for _, eventType := range sortedEventTypes(u.EventTypeCount) {
+		sorts = append(sorts, []string{eventType})
+	}
+	ranks := make([]user.Rank, 0, len(sorts))
+	for _, sortFields := range sorts {
+		sortFn, err := getSortFunction(sortFields)
+		if err != nil {
+			return err
+		}
+		ranks = append(ranks, userAnalyzer.GetRank(u, sortFn))
+	}
+
+	// print the result in given format
+	if format == "json" {
+		return printUserProfileJSON(u, persona.Classify(u, thresholds), activities, sorts, ranks)
+	}
+	printUserProfile(u, persona.Classify(u, thresholds), activities, sorts, ranks)
+
+	return nil
+}
+
+// sortedEventTypes returns the event types sorted by count & then by name
+func sortedEventTypes(eventTypeCount map[string]int) []string {
+	eventTypes := make([]string, 0, len(eventTypeCount))
+	for eventType := range eventTypeCount {
+		eventTypes = append(eventTypes, eventType)
+	}
+	sort.Slice(eventTypes, func(i, j int) bool {
+		if eventTypeCount[eventTypes[i]] == eventTypeCount[eventTypes[j]] {
+			return eventTypes[i] < eventTypes[j]
+		}
+		return eventTypeCount[eventTypes[i]] > eventTypeCount[eventTypes[j]]
+	})
+	return eventTypes
+}
+
+// printUserProfile print the profile of the user in readable format
+func printUserProfile(u user.User, p persona.Persona, activities []user.RepoActivity, sorts [][]string, ranks []user.Rank) {
+	var str strings.Builder
+	fmt.Fprintf(&str, "ID:%s Username:%s Persona:%s \nCommits:%d DistinctCommits:%d Repos:%d \n", u.ID, u.Username, p, u.CommitCount, u.DistinctCommitCount, u.RepoCount)
+	for _, eventType := range sortedEventTypes(u.EventTypeCount) {
+		fmt.Fprintf(&str, "%s:%d Repos:%d \n", eventType, u.EventTypeCount[eventType], u.EventTypeRepoCount[eventType])
+	}
+	fmt.Printf("User \n --- \n%s --- \n", str.String())
+
+	// the activity on own repos & on the repos of others
+	str.Reset()
+	for _, scope := range []string{user.OwnScope, user.ExternalScope} {
+		scoped, _ := u.Scoped(scope)
+		fmt.Fprintf(&str, "%s Commits:%d DistinctCommits:%d Repos:%d ", scope, scoped.CommitCount, scoped.DistinctCommitCount, scoped.RepoCount)
+		for _, eventType := range sortedEventTypes(scoped.EventTypeCount) {
+			fmt.Fprintf(&str, "%s:%d ", eventType, scoped.EventTypeCount[eventType])
+		}
+		fmt.Fprint(&str, "\n")
+	}
+	fmt.Printf("Own vs external repos \n --- \n%s --- \n", str.String())
+
+	str.Reset()
+	for _, activity := range activities {
+		fmt.Fprintf(&str, "Events:%d Commits:%d ", activity.EventCount, activity.CommitCount)
+		for _, eventType := range sortedEventTypes(activity.EventTypeCount) {
+			fmt.Fprintf(&str, "%s:%d ", eventType, activity.EventTypeCount[eventType])
+		}
+		fmt.Fprintf(&str, "ID:%s Name:%s \n", activity.ID, activity.Name)
+	}
+	fmt.Printf("Repos by activity \n --- \n%s --- \n", str.String())
+
+	str.Reset()
+	for i, rank := range ranks {
+		fmt.Fprintf(&str, "Rank:%d/%d Percentile:%.2f Sort:%v \n", rank.Rank, rank.Total, rank.Percentile, sorts[i])
+	}
+	fmt.Printf("Ranks among users \n --- \n%s --- \n", str.String())
+}
+
+// activityJSON is the activity of a user, in all or some repos, in json format
+type activityJSON struct {
+	Commits         int            `json:"commits"`
+	DistinctCommits int            `json:"distinctCommits"`
+	Repos           int            `json:"repos"`
+	EventTypes      map[string]int `json:"eventTypes"`
+}
+
+// rankJSON is the rank of a user or a repo under a sort in json format
+type rankJSON struct {
+	Sort       []string `json:"sort"`
+	Rank       int      `json:"rank"`
+	Total      int      `json:"total"`
+	Percentile float64  `json:"percentile"`
+}
+
+// userProfileJSON is the profile of a user in json format
+type userProfileJSON struct {
+	ID             string                  `json:"id"`
+	Username       string                  `json:"username"`
+	Persona        persona.Persona         `json:"persona"`
+	Activity       activityJSON            `json:"activity"`
+	EventTypeRepos map[string]int          `json:"eventTypeRepos"`
+	Scopes         map[string]activityJSON `json:"scopes"`
+	Repos          []repoActivityJSON      `json:"repos"`
+	Ranks          []rankJSON              `json:"ranks"`
+}
+
+// printUserProfileJSON print the profile of the user in json format
+func printUserProfileJSON(u user.User, p persona.Persona, activities []user.RepoActivity, sorts [][]string, ranks []user.Rank) error {
+	out := userProfileJSON{
+		ID:             u.ID,
+		Username:       u.Username,
+		Persona:        p,
+		Activity:       userActivityJSON(u),
+		EventTypeRepos: u.EventTypeRepoCount,
+		Scopes:         map[string]activityJSON{},
+		Repos:          make([]repoActivityJSON, 0, len(activities)),
+		Ranks:          make([]rankJSON, 0, len(ranks)),
+	}
+	for _, scope := range []string{user.OwnScope, user.ExternalScope} {
+		scoped, _ := u.Scoped(scope)
+		out.Scopes[scope] = userActivityJSON(scoped)
+	}
+	for _, activity := range activities {
+		out.Repos = append(out.Repos, repoActivityJSON{ID: activity.ID, Name: activity.Name, Events: activity.EventCount,
+			Commits: activity.CommitCount, EventTypes: activity.EventTypeCount})
+	}
+	for i, rank := range ranks {
+		out.Ranks = append(out.Ranks, rankJSON{Sort: sorts[i], Rank: rank.Rank, Total: rank.Total, Percentile: rank.Percentile})
+	}
+	return printJSON(out)
+}
+
+// userActivityJSON returns the activity of the user in json format
+func userActivityJSON(u user.User) activityJSON {
+	eventTypes := u.EventTypeCount
+	if eventTypes == nil {
+		eventTypes = map[string]int{}
+	}
+	return activityJSON{Commits: u.CommitCount, DistinctCommits: u.DistinctCommitCount, Repos: u.RepoCount, EventTypes: eventTypes}
+}
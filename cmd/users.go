@@ -1,10 +1,12 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 
 	"github.com/ameykpatil/github-data-analyzer/db"
+	"github.com/ameykpatil/github-data-analyzer/domain/persona"
 	"github.com/ameykpatil/github-data-analyzer/domain/user"
 	"github.com/ameykpatil/github-data-analyzer/service"
 	"github.com/spf13/cobra"
@@ -19,8 +21,18 @@ func NewUsersCmd() *cobra.Command {
 	}
 
 	usersCmd.Flags().StringP("path", "p", "", "path of the directory where the data files are")
-	usersCmd.Flags().Uint32P("limit", "l", 10, "number of users to return")
-	usersCmd.Flags().StringSliceP("sort", "s", []string{"prs,commits"}, "fields to sort by")
+	usersCmd.Flags().Uint32P("limit", "l", 10, "number of users to return, 0 returns all")
+	usersCmd.Flags().Uint32P("offset", "o", 0, "number of top users to skip")
+	usersCmd.Flags().StringSliceP("sort", "s", []string{"prs", "commits"}, "fields to sort by, descending unless prefixed with + or suffixed with :asc")
+	usersCmd.Flags().Uint32("per-user-repos", 0, "number of top repos to list for each of the top users, 0 lists none")
+	usersCmd.Flags().String("persona", "", "persona of the users to rank e.g. coder, reviewer, triager, maintainer, stargazer or other, all by default")
+
+	addFormatFlag(usersCmd)
+	addPersonaFlags(usersCmd)
+
+	addRankFlags(usersCmd)
+	addInfluenceFlags(usersCmd)
+	addApproximateFlags(usersCmd)
 
 	return usersCmd
 }
@@ -35,10 +47,48 @@ func getTopUsers(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	offset, err := cmd.Flags().GetUint32("offset")
+	if err != nil {
+		return err
+	}
 	sortFields, err := cmd.Flags().GetStringSlice("sort")
 	if err != nil {
 		return err
 	}
+	perUserRepos, err := cmd.Flags().GetUint32("per-user-repos")
+	if err != nil {
+		return err
+	}
+	format, err := getFormat(cmd)
+	if err != nil {
+		return err
+	}
+	personaName, err := cmd.Flags().GetString("persona")
+	if err != nil {
+		return err
+	}
+	thresholds, err := getPersonaThresholds(cmd)
+	if err != nil {
+		return err
+	}
+	rankOptions, err := getRankOptions(cmd)
+	if err != nil {
+		return err
+	}
+	influenceOptions, err := getInfluenceOptions(cmd)
+	if err != nil {
+		return err
+	}
+	approximate, err := cmd.Flags().GetBool("approximate")
+	if err != nil {
+		return err
+	}
+	if approximate {
+		if perUserRepos > 0 || format != "text" || personaName != "" {
+			return errors.New("approximate mode supports neither per-user-repos, persona nor json format")
+		}
+		return getApproximateTopUsers(cmd, path, limit, offset, sortFields)
+	}
 
 	// create custom sort function
 	sortFn, err := getSortFunction(sortFields)
@@ -46,6 +96,18 @@ func getTopUsers(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	// keep only the users of the persona if any
+	var keep func(u user.User) bool
+	if personaName != "" {
+		p, err := persona.Parse(personaName)
+		if err != nil {
+			return err
+		}
+		keep = func(u user.User) bool {
+			return persona.Classify(u, thresholds) == p
+		}
+	}
+
 	// initialise dependencies
 	dataStore, err := db.NewDataStore(path)
 	if err != nil {
@@ -53,71 +115,116 @@ func getTopUsers(cmd *cobra.Command, args []string) error {
 	}
 	eventHandler := service.NewEventHandler(dataStore)
 	userAnalyzer := user.NewAnalyzer(*eventHandler)
+	setInfluence(eventHandler, influenceOptions, sortFields, userAnalyzer, nil)
 
 	// get the top users
-	users := userAnalyzer.GetTopUsers(limit, sortFn)
+	users := userAnalyzer.GetRankedUsersWhere(limit, offset, sortFn, rankOptions, keep)
+
+	// get the top repos of all the top users at once
+	var reposPerUser map[string][]user.RepoActivity
+	if perUserRepos > 0 {
+		userIDs := make([]string, 0, len(users))
+		for _, u := range users {
+			userIDs = append(userIDs, u.ID)
+		}
+		reposPerUser = userAnalyzer.GetTopReposPerUser(userIDs, perUserRepos)
+	}
 
-	// print the result in readable format
-	printUsers(users, limit, sortFields)
+	// print the result in given format
+	if format == "json" {
+		return printUsersJSON(users, sortFields, reposPerUser, thresholds)
+	}
+	if err := printUsers(users, limit, sortFields, reposPerUser, thresholds); err != nil {
+		return err
+	}
 
 	return nil
 
 }
 
-// getSortFunction creates multilevel wrapped function based on sortFields
-func getSortFunction(sortFields []string) (func(ui, uj user.User) bool, error) {
-	sortFn := func(ui, uj user.User) bool {
-		return true
+// getApproximateTopUsers gets & prints the estimated top users by the sort field
+func getApproximateTopUsers(cmd *cobra.Command, path string, limit, offset uint32, sortFields []string) error {
+	metric, err := getApproximateMetric(cmd, sortFields, userMetricNames())
+	if err != nil {
+		return err
+	}
+	summary, err := getSummary(cmd, path)
+	if err != nil {
+		return err
 	}
+	printEstimates("Users", summary.GetTopUsers(metric, limit, offset), limit, metric, summary.GetDistinctUsers(""))
+	return nil
+}
 
-	for i := len(sortFields) - 1; i >= 0; i-- {
-		field := sortFields[i]
-		sortFn = wrap(sortFn, field)
+// getSortFunction creates multilevel sort function based on sortFields
+func getSortFunction(sortFields []string) (func(ui, uj user.User) bool, error) {
+	columns, err := getUserColumns(sortFields)
+	if err != nil {
+		return nil, err
 	}
+	return func(ui, uj user.User) bool {
+		return lessByColumns(columns.columns, func(i int) (float64, float64) {
+			return columns.values[i](ui), columns.values[i](uj)
+		})
+	}, nil
+}
 
-	return sortFn, nil
+// getUserValue returns the label & the function to get the value of the sort field (metric or score) of a user
+func getUserValue(field string) (string, func(u user.User) float64, error) {
+	if isScoreField(field) {
+		return getUserScore(field)
+	}
+	value, err := getUserMetric(field)
+	return field, value, err
 }
 
-// wrap function wraps the given function with sorting of the given field to return a new function
-func wrap(f func(ui, uj user.User) bool, field string) func(ui, uj user.User) bool {
-	switch field {
-	case "Commits":
-		return func(ui, uj user.User) bool {
-			if ui.CommitCount == uj.CommitCount {
-				return f(ui, uj)
-			} else if ui.CommitCount > uj.CommitCount {
-				return true
-			}
-			return false
-		}
-	default:
-		if strings.Contains(field, "Event") {
-			return func(ri, rj user.User) bool {
-				if ri.EventTypeCount[field] == rj.EventTypeCount[field] {
-					return f(ri, rj)
-				} else if ri.EventTypeCount[field] > rj.EventTypeCount[field] {
-					return true
-				}
-				return false
-			}
-		}
-		return f
+// splitScope splits the field into the scope of the activity & the field e.g. External & Commits for External:Commits
+// the scope is empty for the fields without scope
+func splitScope(field string) (string, string) {
+	if i := strings.Index(field, ":"); i >= 0 {
+		return field[:i], field[i+1:]
 	}
+	return "", field
+}
+
+// userColumns are the sort fields of users with the functions to get their values, resolved once for all the rows
+type userColumns struct {
+	columns []sortColumn
+	values  []func(u user.User) float64
 }
 
-// printUsers print users in readable format
-func printUsers(users []user.User, limit uint32, sortFields []string) {
+// getUserColumns resolves the sort fields of users & the functions to get their values
+func getUserColumns(sortFields []string) (userColumns, error) {
+	var columns userColumns
+	var err error
+	columns.columns, err = resolveSortColumns(sortFields, func(field string) (string, error) {
+		label, value, err := getUserValue(field)
+		columns.values = append(columns.values, value)
+		return label, err
+	})
+	return columns, err
+}
+
+// printUsers print users in readable format along with their top repos (if any) indented below each user
+// the rank is printed first & Tie tells if the user has the same sort key as another one, the persona is printed last
+func printUsers(users []user.RankedUser, limit uint32, sortFields []string, reposPerUser map[string][]user.RepoActivity, thresholds persona.Thresholds) error {
+	columns, err := getUserColumns(sortFields)
+	if err != nil {
+		return err
+	}
+
 	var str strings.Builder
-	for _, user := range users {
-		for _, sortField := range sortFields {
-			if sortField == "Commits" {
-				fmt.Fprintf(&str, "%s:%d ", sortField, user.CommitCount)
-			} else if strings.Contains(sortField, "Event") {
-				fmt.Fprintf(&str, "%s:%d ", sortField, user.EventTypeCount[sortField])
-			}
+	for _, u := range users {
+		fmt.Fprintf(&str, "Rank:%d Tie:%t ", u.Rank, u.Tie)
+		for i, column := range columns.columns {
+			fmt.Fprintf(&str, "%s:%s ", column.label, column.format(columns.values[i](u.User)))
+		}
+		fmt.Fprintf(&str, "ID:%s Username:%s Persona:%s \n", u.ID, u.Username, persona.Classify(u.User, thresholds))
+		for _, activity := range reposPerUser[u.ID] {
+			fmt.Fprintf(&str, "    Events:%d Commits:%d ID:%s Name:%s \n", activity.EventCount, activity.CommitCount, activity.ID, activity.Name)
 		}
-		fmt.Fprintf(&str, "ID:%s Username:%s \n", user.ID, user.Username)
 	}
 
 	fmt.Printf("Top %d Users by %v \n --- \n%s --- \n", limit, sortFields, str.String())
+	return nil
 }
@@ -1,17 +1,13 @@
 package db
 
 import (
-	"encoding/csv"
-	"io"
-	"os"
-
 	"github.com/ameykpatil/github-data-analyzer/db/entities"
 )
 
 // DataStore is a collection of entities read from files
 type DataStore struct {
 	ActorStore  map[string]*entities.Actor
-	CommitStore map[string]*entities.Commit
+	CommitStore map[string][]*entities.Commit
 	EventStore  map[string]*entities.Event
 	RepoStore   map[string]*entities.Repo
 }
@@ -48,135 +44,61 @@ func NewDataStore(path string) (*DataStore, error) {
 
 func readActors(path string) (map[string]*entities.Actor, error) {
 	actorStore := make(map[string]*entities.Actor)
-
-	in, err := os.Open(path + "/actors.csv")
+	err := ReadRecords(path, ActorsFile, func(record []string) {
+		actor := ParseActor(record)
+		actorStore[actor.ID] = actor
+	})
 	if err != nil {
 		return nil, err
 	}
-	defer in.Close()
-
-	reader := csv.NewReader(in)
-
-	// read & skip the first header record
-	if _, err := reader.Read(); err != nil {
-		return nil, err
-	}
-
-	for {
-		record, err := reader.Read()
-		if err == io.EOF {
-			break
-		} else if err != nil {
-			return nil, err
-		}
-		actor := &entities.Actor{
-			ID:       record[0],
-			Username: record[1],
-		}
-		actorStore[actor.ID] = actor
-	}
-
 	return actorStore, nil
 }
 
-func readCommits(path string) (map[string]*entities.Commit, error) {
-	commitStore := make(map[string]*entities.Commit)
-
-	in, err := os.Open(path + "/commits.csv")
+// readCommits reads commits grouped by sha, the same sha can be pushed with multiple events (e.g. to forks)
+func readCommits(path string) (map[string][]*entities.Commit, error) {
+	commitStore := make(map[string][]*entities.Commit)
+	err := ReadRecords(path, CommitsFile, func(record []string) {
+		commit := ParseCommit(record)
+		if !containsEvent(commitStore[commit.Sha], commit.EventID) {
+			commitStore[commit.Sha] = append(commitStore[commit.Sha], commit)
+		}
+	})
 	if err != nil {
 		return nil, err
 	}
-	defer in.Close()
-
-	reader := csv.NewReader(in)
-
-	// read & skip the first header record
-	if _, err := reader.Read(); err != nil {
-		return nil, err
-	}
-
-	for {
-		record, err := reader.Read()
-		if err == io.EOF {
-			break
-		} else if err != nil {
-			return nil, err
-		}
-		commit := &entities.Commit{
-			Sha:     record[0],
-			Message: record[1],
-			EventID: record[2],
-		}
-		commitStore[commit.Sha] = commit
-	}
-
 	return commitStore, nil
 }
 
 func readEvents(path string) (map[string]*entities.Event, error) {
 	eventStore := make(map[string]*entities.Event)
-
-	in, err := os.Open(path + "/events.csv")
+	err := ReadRecords(path, EventsFile, func(record []string) {
+		event := ParseEvent(record)
+		eventStore[event.ID] = event
+	})
 	if err != nil {
 		return nil, err
 	}
-	defer in.Close()
-
-	reader := csv.NewReader(in)
-
-	// read & skip the first header record
-	if _, err := reader.Read(); err != nil {
-		return nil, err
-	}
-
-	for {
-		record, err := reader.Read()
-		if err == io.EOF {
-			break
-		} else if err != nil {
-			return nil, err
-		}
-		event := &entities.Event{
-			ID:      record[0],
-			Type:    record[1],
-			ActorID: record[2],
-			RepoID:  record[3],
-		}
-		eventStore[event.ID] = event
-	}
-
 	return eventStore, nil
 }
 
 func readRepos(path string) (map[string]*entities.Repo, error) {
 	repoStore := make(map[string]*entities.Repo)
-
-	in, err := os.Open(path + "/repos.csv")
+	err := ReadRecords(path, ReposFile, func(record []string) {
+		repo := ParseRepo(record)
+		repoStore[repo.ID] = repo
+	})
 	if err != nil {
 		return nil, err
 	}
-	defer in.Close()
-
-	reader := csv.NewReader(in)
-
-	// read & skip the first header record
-	if _, err := reader.Read(); err != nil {
-		return nil, err
-	}
+	return repoStore, nil
+}
 
-	for {
-		record, err := reader.Read()
-		if err == io.EOF {
-			break
-		} else if err != nil {
-			return nil, err
-		}
-		repo := &entities.Repo{
-			ID:   record[0],
-			Name: record[1],
+// containsEvent checks if any of the commits belongs to the given event
+func containsEvent(commits []*entities.Commit, eventID string) bool {
+	for _, commit := range commits {
+		if commit.EventID == eventID {
+			return true
 		}
-		repoStore[repo.ID] = repo
 	}
-
-	return repoStore, nil
+	return false
 }
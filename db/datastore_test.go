@@ -39,16 +39,20 @@ func TestReadActorsError(t *testing.T) {
 
 func TestReadCommits(t *testing.T) {
 
-	expected := map[string]*entities.Commit{
+	expected := map[string][]*entities.Commit{
 		"5948a6cc5255015e983a9719117c15ff197b4681": {
-			Sha:     "5948a6cc5255015e983a9719117c15ff197b4681",
-			Message: "Refactor member inde",
-			EventID: "11185376329",
+			{
+				Sha:     "5948a6cc5255015e983a9719117c15ff197b4681",
+				Message: "Refactor member inde",
+				EventID: "11185376329",
+			},
 		},
 		"bf7296401598660b44d8923787a2600f346f9a81": {
-			Sha:     "bf7296401598660b44d8923787a2600f346f9a81",
-			Message: "Refactor roadmap",
-			EventID: "11185376329",
+			{
+				Sha:     "bf7296401598660b44d8923787a2600f346f9a81",
+				Message: "Refactor roadmap",
+				EventID: "11185376329",
+			},
 		},
 	}
 
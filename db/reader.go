@@ -0,0 +1,103 @@
+package db
+
+import (
+	"encoding/csv"
+	"io"
+	"os"
+
+	"github.com/ameykpatil/github-data-analyzer/db/entities"
+)
+
+// names of the data files in the path
+const (
+	ActorsFile  = "actors.csv"
+	CommitsFile = "commits.csv"
+	EventsFile  = "events.csv"
+	ReposFile   = "repos.csv"
+)
+
+// RecordReader reads the records of a data file one at a time, so the file is never held in memory
+type RecordReader struct {
+	in     *os.File
+	reader *csv.Reader
+}
+
+// NewRecordReader opens the data file in path & skips its header record
+func NewRecordReader(path, file string) (*RecordReader, error) {
+	in, err := os.Open(path + "/" + file)
+	if err != nil {
+		return nil, err
+	}
+	reader := csv.NewReader(in)
+
+	// read & skip the first header record
+	if _, err := reader.Read(); err != nil {
+		in.Close()
+		return nil, err
+	}
+	return &RecordReader{in: in, reader: reader}, nil
+}
+
+// Read returns the next record, io.EOF at the end of the file
+func (rr *RecordReader) Read() ([]string, error) {
+	return rr.reader.Read()
+}
+
+// Close closes the data file
+func (rr *RecordReader) Close() error {
+	return rr.in.Close()
+}
+
+// ReadRecords passes every record of the data file in path to fn, one at a time
+func ReadRecords(path, file string, fn func(record []string)) error {
+	reader, err := NewRecordReader(path, file)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		fn(record)
+	}
+}
+
+// ParseActor creates the actor of a record of actors csv
+func ParseActor(record []string) *entities.Actor {
+	return &entities.Actor{
+		ID:       record[0],
+		Username: record[1],
+	}
+}
+
+// ParseCommit creates the commit of a record of commits csv
+func ParseCommit(record []string) *entities.Commit {
+	return &entities.Commit{
+		Sha:     record[0],
+		Message: record[1],
+		EventID: record[2],
+	}
+}
+
+// ParseEvent creates the event of a record of events csv
+func ParseEvent(record []string) *entities.Event {
+	return &entities.Event{
+		ID:      record[0],
+		Type:    record[1],
+		ActorID: record[2],
+		RepoID:  record[3],
+	}
+}
+
+// ParseRepo creates the repo of a record of repos csv
+func ParseRepo(record []string) *entities.Repo {
+	return &entities.Repo{
+		ID:   record[0],
+		Name: record[1],
+	}
+}
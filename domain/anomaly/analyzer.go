@@ -0,0 +1,183 @@
+package anomaly
+
+import (
+	"math"
+	"sort"
+
+	"github.com/ameykpatil/github-data-analyzer/domain/repo"
+	"github.com/ameykpatil/github-data-analyzer/domain/topk"
+	"github.com/ameykpatil/github-data-analyzer/domain/user"
+)
+
+// metric names which are not an event type share
+const (
+	CommitsPerPush = "CommitsPerPush"
+	LogEvents      = "LogEvents"
+)
+
+// minimum deviations of the metrics, i.e. 1 commit per push, ~26% more events & 5 points of event type share
+const (
+	minCommitsPerPushScale = 1
+	minLogEventsScale      = 0.1
+	minShareScale          = 0.05
+)
+
+// priorEvents is the weight (in events) of the median of a ratio metric i.e. share of an event type or commits per
+// push, the value of every profile is shrunk toward it so that a few events e.g. the only event of a user are less
+// unusual than a lot of them
+const priorEvents = 10
+
+// Analyzer encapsulates functionality of detecting unusual users & repos
+type Analyzer struct {
+	userAnalyzer *user.Analyzer
+	repoAnalyzer *repo.Analyzer
+}
+
+// NewAnalyzer creates a new instance of anomaly Analyzer
+func NewAnalyzer(userAnalyzer *user.Analyzer, repoAnalyzer *repo.Analyzer) *Analyzer {
+	return &Analyzer{
+		userAnalyzer: userAnalyzer,
+		repoAnalyzer: repoAnalyzer,
+	}
+}
+
+// profile is the activity of a user or a repo used to compute the metrics
+type profile struct {
+	id             string
+	name           string
+	commitCount    int
+	eventTypeCount map[string]int
+}
+
+// GetUserAnomalies returns the most unusual users with at least minEvents events based on provided limit & offset
+func (aa *Analyzer) GetUserAnomalies(minEvents int, limit, offset uint32) []Anomaly {
+	profiles := []profile{}
+	for _, u := range aa.userAnalyzer.GetUsers() {
+		profiles = append(profiles, profile{id: u.ID, name: u.Username, commitCount: u.CommitCount, eventTypeCount: u.EventTypeCount})
+	}
+	return score(profiles, minEvents, limit, offset)
+}
+
+// GetRepoAnomalies returns the most unusual repos with at least minEvents events based on provided limit & offset
+func (aa *Analyzer) GetRepoAnomalies(minEvents int, limit, offset uint32) []Anomaly {
+	profiles := []profile{}
+	for _, r := range aa.repoAnalyzer.GetRepos() {
+		profiles = append(profiles, profile{id: r.ID, name: r.Name, commitCount: r.CommitCount, eventTypeCount: r.EventTypeCount})
+	}
+	return score(profiles, minEvents, limit, offset)
+}
+
+// score computes the metrics of the profiles, i.e. (shrunk) share of each event type, commits per push & log of events,
+// & returns the top anomalies by their highest robust z-score, only values above the median are considered unusual
+// so the profiles without any metric above the median are left out
+func score(all []profile, minEvents int, limit, offset uint32) []Anomaly {
+	profiles := []profile{}
+	eventTypeSet := map[string]bool{}
+	for _, p := range all {
+		if events(p) >= minEvents && events(p) > 0 {
+			profiles = append(profiles, p)
+			for eventType := range p.eventTypeCount {
+				eventTypeSet[eventType] = true
+			}
+		}
+	}
+	metrics := []string{CommitsPerPush, LogEvents}
+	for eventType := range eventTypeSet {
+		metrics = append(metrics, eventType)
+	}
+	sort.Strings(metrics[2:])
+
+	anomalies := make([]Anomaly, len(profiles))
+	for i, p := range profiles {
+		anomalies[i] = Anomaly{ID: p.id, Name: p.name}
+	}
+	for _, metric := range metrics {
+		values := make([]float64, len(profiles))
+		for i, p := range profiles {
+			values[i] = value(p, metric)
+		}
+		if len(present(values)) == 0 {
+			continue
+		}
+		if metric != LogEvents {
+			values = shrink(values, profiles, metric)
+		}
+		scores := robustZ(values, minScale(metric))
+		m := median(present(values))
+		for i := range anomalies {
+			if !math.IsNaN(scores[i]) && scores[i] > anomalies[i].Score {
+				anomalies[i].Score = scores[i]
+				anomalies[i].Metric = metric
+				anomalies[i].Value = values[i]
+				anomalies[i].Median = m
+			}
+		}
+	}
+
+	// the profiles with no metric above the median are not unusual at all
+	unusual := make([]Anomaly, 0, len(anomalies))
+	for _, a := range anomalies {
+		if a.Score > 0 {
+			unusual = append(unusual, a)
+		}
+	}
+	anomalies = unusual
+
+	indices := topk.Select(anomalyRanking(anomalies), int(limit), int(offset))
+	top := make([]Anomaly, 0, len(indices))
+	for _, i := range indices {
+		top = append(top, anomalies[i])
+	}
+	return top
+}
+
+// events returns the total number of events of the profile
+func events(p profile) int {
+	total := 0
+	for _, count := range p.eventTypeCount {
+		total += count
+	}
+	return total
+}
+
+// value returns the value of the metric for the profile, NaN if it is not defined
+func value(p profile, metric string) float64 {
+	switch metric {
+	case CommitsPerPush:
+		if p.eventTypeCount["PushEvent"] == 0 {
+			return math.NaN()
+		}
+		return float64(p.commitCount) / float64(p.eventTypeCount["PushEvent"])
+	case LogEvents:
+		return math.Log10(float64(events(p)))
+	default:
+		return float64(p.eventTypeCount[metric]) / float64(events(p))
+	}
+}
+
+// shrink returns the values of a ratio metric shrunk toward their median, the value of a profile with n events
+// (pushes for commits per push) becomes (n*value + priorEvents*median) / (n + priorEvents)
+func shrink(values []float64, profiles []profile, metric string) []float64 {
+	m := median(present(values))
+	shrunk := make([]float64, len(values))
+	for i, v := range values {
+		n := float64(events(profiles[i]))
+		if metric == CommitsPerPush {
+			n = float64(profiles[i].eventTypeCount["PushEvent"])
+		}
+		shrunk[i] = (n*v + priorEvents*m) / (n + priorEvents)
+	}
+	return shrunk
+}
+
+// minScale returns the minimum deviation of the metric
+func minScale(metric string) float64 {
+	switch metric {
+	case CommitsPerPush:
+		return minCommitsPerPushScale
+	case LogEvents:
+		return minLogEventsScale
+	default:
+		return minShareScale
+	}
+}
@@ -0,0 +1,71 @@
+package anomaly
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScore(t *testing.T) {
+	profiles := []profile{
+		{id: "1", name: "pusher", commitCount: 10, eventTypeCount: map[string]int{"PushEvent": 10}},
+		{id: "2", name: "coder", commitCount: 12, eventTypeCount: map[string]int{"PushEvent": 10, "IssuesEvent": 1}},
+		{id: "3", name: "committer", commitCount: 9, eventTypeCount: map[string]int{"PushEvent": 9, "IssuesEvent": 1}},
+		{id: "4", name: "bulk", commitCount: 900, eventTypeCount: map[string]int{"PushEvent": 9}},
+		{id: "5", name: "forker", eventTypeCount: map[string]int{"ForkEvent": 10}},
+		{id: "6", name: "quiet", commitCount: 1, eventTypeCount: map[string]int{"ForkEvent": 1}},
+	}
+
+	anomalies := score(profiles, 5, 2, 0)
+	assert.Equal(t, 2, len(anomalies))
+
+	assert.Equal(t, "4", anomalies[0].ID)
+	assert.Equal(t, CommitsPerPush, anomalies[0].Metric)
+	// the ratios are shrunk toward the median by 10 events (pushes), i.e. 100 commits per push of 9 pushes
+	assert.InDelta(t, (900+10*1.1)/19, anomalies[0].Value, 1e-9)
+	assert.InDelta(t, 1.1, anomalies[0].Median, 0.01)
+
+	assert.Equal(t, "5", anomalies[1].ID)
+	assert.Equal(t, "ForkEvent", anomalies[1].Metric)
+	assert.Equal(t, 0.5, anomalies[1].Value)
+	assert.Equal(t, 0.0, anomalies[1].Median)
+
+	// users with less than min events are not scored
+	for _, anomaly := range score(profiles, 5, 0, 0) {
+		assert.NotEqual(t, "6", anomaly.ID)
+	}
+
+	// every anomaly has a metric above the median
+	for _, anomaly := range score(profiles, 5, 0, 0) {
+		assert.Greater(t, anomaly.Score, 0.0)
+		assert.NotEmpty(t, anomaly.Metric)
+	}
+
+	// profiles without any metric above the median are not unusual
+	typical := []profile{
+		{id: "1", name: "pusher", commitCount: 10, eventTypeCount: map[string]int{"PushEvent": 10}},
+		{id: "2", name: "pusher", commitCount: 10, eventTypeCount: map[string]int{"PushEvent": 10}},
+		{id: "3", name: "slower", commitCount: 9, eventTypeCount: map[string]int{"PushEvent": 9}},
+	}
+	assert.Equal(t, 0, len(score(typical, 1, 0, 0)))
+
+	assert.Equal(t, 0, len(score(profiles, 100, 0, 0)))
+}
+
+func TestScoreVolume(t *testing.T) {
+	profiles := []profile{
+		{id: "1", name: "forker", eventTypeCount: map[string]int{"ForkEvent": 100}},
+		{id: "2", name: "once", eventTypeCount: map[string]int{"WatchEvent": 1}},
+	}
+	for i := 3; i < 23; i++ {
+		profiles = append(profiles, profile{id: strconv.Itoa(i), name: "pusher", commitCount: 20, eventTypeCount: map[string]int{"PushEvent": 20}})
+	}
+
+	// both have the whole of their activity in an unusual event type, but the forker has a lot more of it
+	anomalies := score(profiles, 1, 2, 0)
+	assert.Equal(t, "1", anomalies[0].ID)
+	assert.Equal(t, "ForkEvent", anomalies[0].Metric)
+	assert.Greater(t, anomalies[0].Score, anomalies[1].Score)
+	assert.Equal(t, "2", anomalies[1].ID)
+}
@@ -0,0 +1,30 @@
+package anomaly
+
+// Anomaly encapsulates how unusual a user or a repo is
+// Score is the highest robust z-score among the metrics & Metric is the one which triggered it
+type Anomaly struct {
+	ID     string
+	Name   string
+	Score  float64
+	Metric string
+	Value  float64
+	Median float64
+}
+
+// anomalyRanking ranks the anomalies by score (topk Interface)
+type anomalyRanking []Anomaly
+
+// Len is the number of anomalies to rank
+func (r anomalyRanking) Len() int {
+	return len(r)
+}
+
+// Less reports whether the anomaly i ranks before the anomaly j
+func (r anomalyRanking) Less(i, j int) bool {
+	return r[i].Score > r[j].Score
+}
+
+// ID is used to break the ties between anomalies
+func (r anomalyRanking) ID(i int) string {
+	return r[i].ID
+}
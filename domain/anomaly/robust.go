@@ -0,0 +1,72 @@
+package anomaly
+
+import (
+	"math"
+	"sort"
+)
+
+const (
+	// madScale makes the MAD consistent with the standard deviation of normally distributed values
+	madScale = 0.6745
+	// meanADScale is used instead of madScale when more than half the values are equal & MAD is 0
+	meanADScale = 0.7979
+)
+
+// robustZ returns the modified z-scores (median & MAD based) of the values, NaN values are ignored
+// if MAD is 0, the mean absolute deviation is used instead, the deviation is never taken smaller than minScale
+// so that a metric which is almost always equal to the median (e.g. share of a rare event type) does not dominate
+func robustZ(values []float64, minScale float64) []float64 {
+	scores := make([]float64, len(values))
+	known := present(values)
+	if len(known) == 0 {
+		return scores
+	}
+
+	m := median(known)
+	deviations := make([]float64, len(known))
+	meanAD := 0.0
+	for i, value := range known {
+		deviations[i] = math.Abs(value - m)
+		meanAD += deviations[i]
+	}
+	meanAD /= float64(len(known))
+	mad := median(deviations)
+
+	scale := mad / madScale
+	if mad == 0 {
+		scale = meanAD / meanADScale
+	}
+	scale = math.Max(scale, minScale)
+
+	for i, value := range values {
+		switch {
+		case math.IsNaN(value):
+			scores[i] = math.NaN()
+		case scale > 0:
+			scores[i] = (value - m) / scale
+		}
+	}
+	return scores
+}
+
+// present returns the values which are not NaN
+func present(values []float64) []float64 {
+	result := []float64{}
+	for _, value := range values {
+		if !math.IsNaN(value) {
+			result = append(result, value)
+		}
+	}
+	return result
+}
+
+// median returns the median of the values
+func median(values []float64) float64 {
+	sorted := append([]float64{}, values...)
+	sort.Float64s(sorted)
+	n := len(sorted)
+	if n%2 == 1 {
+		return sorted[n/2]
+	}
+	return (sorted[n/2-1] + sorted[n/2]) / 2
+}
@@ -0,0 +1,68 @@
+package anomaly
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRobustZ(t *testing.T) {
+	nan := math.NaN()
+	tests := []struct {
+		name     string
+		values   []float64
+		minScale float64
+		exp      []float64
+	}{
+		{
+			name:   "no values",
+			values: []float64{},
+			exp:    []float64{},
+		},
+		{
+			name:   "equal values",
+			values: []float64{2, 2, 2},
+			exp:    []float64{0, 0, 0},
+		},
+		{
+			name:   "mad",
+			values: []float64{1, 2, 3, 4, 100},
+			exp:    []float64{-2 * madScale, -madScale, 0, madScale, 97 * madScale},
+		},
+		{
+			name:   "mean absolute deviation when mad is 0",
+			values: []float64{0, 0, 0, 0, 5},
+			exp:    []float64{0, 0, 0, 0, 5 * meanADScale},
+		},
+		{
+			name:     "min scale",
+			values:   []float64{0, 0, 0, 0, 5},
+			minScale: 5,
+			exp:      []float64{0, 0, 0, 0, 1},
+		},
+		{
+			name:   "nan values are ignored",
+			values: []float64{nan, 1, 2, 3, 4, 100},
+			exp:    []float64{nan, -2 * madScale, -madScale, 0, madScale, 97 * madScale},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scores := robustZ(tt.values, tt.minScale)
+			assert.Equal(t, len(tt.exp), len(scores))
+			for i := range tt.exp {
+				if math.IsNaN(tt.exp[i]) {
+					assert.True(t, math.IsNaN(scores[i]))
+				} else {
+					assert.InDelta(t, tt.exp[i], scores[i], 1e-9)
+				}
+			}
+		})
+	}
+}
+
+func TestMedian(t *testing.T) {
+	assert.Equal(t, 2.0, median([]float64{3, 1, 2}))
+	assert.Equal(t, 2.5, median([]float64{4, 1, 3, 2}))
+}
@@ -0,0 +1,103 @@
+package approx
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/ameykpatil/github-data-analyzer/db"
+	"github.com/ameykpatil/github-data-analyzer/db/entities"
+	"github.com/ameykpatil/github-data-analyzer/domain/sketch"
+)
+
+// SummarizeFiles creates the summary of the data files in path by streaming their records, so only the sketches are held in memory
+// the commits of an event are expected next to each other & in the order of the events, as the data files are exported,
+// the names of the tracked users & repos are set by a last pass over the actors & repos files
+func SummarizeFiles(path string, options Options) (*Summary, error) {
+	summary, err := NewSummary(options)
+	if err != nil {
+		return nil, err
+	}
+	if err := summary.addEvents(path); err != nil {
+		return nil, err
+	}
+
+	err = db.ReadRecords(path, db.ActorsFile, func(record []string) {
+		actor := db.ParseActor(record)
+		setLabel(summary.TopUsers, actor.ID, actor.Username)
+	})
+	if err != nil {
+		return nil, err
+	}
+	err = db.ReadRecords(path, db.ReposFile, func(record []string) {
+		repo := db.ParseRepo(record)
+		setLabel(summary.TopRepos, repo.ID, repo.Name)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return summary, nil
+}
+
+// addEvents streams the events file along with the commits file & adds every event with the number of its distinct commits
+func (s *Summary) addEvents(path string) error {
+	events, err := db.NewRecordReader(path, db.EventsFile)
+	if err != nil {
+		return err
+	}
+	defer events.Close()
+	commits, err := db.NewRecordReader(path, db.CommitsFile)
+	if err != nil {
+		return err
+	}
+	defer commits.Close()
+
+	commit, err := nextCommit(commits)
+	if err != nil {
+		return err
+	}
+	for {
+		record, err := events.Read()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return err
+		}
+		event := db.ParseEvent(record)
+
+		// the same sha can be listed more than once for an event, it is counted once as in the event handler
+		shas := map[string]bool{}
+		for commit != nil && commit.EventID == event.ID {
+			shas[commit.Sha] = true
+			if commit, err = nextCommit(commits); err != nil {
+				return err
+			}
+		}
+		s.add(event.Type, event.ActorID, "", event.RepoID, "", uint64(len(shas)))
+	}
+
+	if commit != nil {
+		return fmt.Errorf("commit %s of event %s is not in the order of the events", commit.Sha, commit.EventID)
+	}
+	return nil
+}
+
+// nextCommit returns the next commit of the reader, nil at the end of the file
+func nextCommit(commits *db.RecordReader) (*entities.Commit, error) {
+	record, err := commits.Read()
+	if err == io.EOF {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return db.ParseCommit(record), nil
+}
+
+// setLabel sets the label of the key in the trackers which track it
+func setLabel(trackers map[string]*sketch.HeavyHitters, key, label string) {
+	for _, hh := range trackers {
+		if item, ok := hh.Items[key]; ok {
+			item.Label = label
+			hh.Items[key] = item
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package approx
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSummarizeFiles(t *testing.T) {
+	summary, err := SummarizeFiles("../../data/test-data", Options{})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(2), summary.Events)
+
+	users := summary.GetTopUsers(Commits, 0, 0)
+	assert.Len(t, users, 1)
+	assert.Equal(t, "8422699", users[0].ID)
+	assert.Equal(t, "Apexal", users[0].Name)
+	assert.Equal(t, uint64(2), users[0].Count)
+
+	repos := summary.GetTopRepos("CreateEvent", 0, 0)
+	assert.Len(t, repos, 1)
+	assert.Equal(t, "ArturoCamacho0/ProjectResponsive", repos[0].Name)
+	assert.InDelta(t, 2, summary.GetDistinctRepos("").Count, 0.01)
+
+	_, err = SummarizeFiles("../../data/test-data1", Options{})
+	assert.Error(t, err)
+}
+
+func TestSummarizeFilesCommitOrder(t *testing.T) {
+	files := map[string]string{
+		"actors.csv": "id,username\n111,Actor1\n",
+		"repos.csv":  "id,name\n441,Actor1/Repo1\n",
+		"events.csv": "id,type,actor_id,repo_id\n331,PushEvent,111,441\n332,PushEvent,111,441\n",
+	}
+	write := func(dir string) {
+		for name, content := range files {
+			assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0644))
+		}
+	}
+
+	// a sha listed twice for an event is counted once
+	files["commits.csv"] = "sha,message,event_id\n221,M1,331\n221,M1,331\n222,M2,332\n"
+	dir := t.TempDir()
+	write(dir)
+	summary, err := SummarizeFiles(dir, Options{})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(2), summary.GetTopRepos(Commits, 0, 0)[0].Count)
+
+	// the commits of an event which is not in the order of the events are not silently dropped
+	files["commits.csv"] = "sha,message,event_id\n222,M2,332\n221,M1,331\n"
+	dir = t.TempDir()
+	write(dir)
+	_, err = SummarizeFiles(dir, Options{})
+	assert.EqualError(t, err, "commit 221 of event 331 is not in the order of the events")
+}
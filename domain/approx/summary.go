@@ -0,0 +1,325 @@
+// Package approx summarizes the activity of users & repos in bounded memory with probabilistic sketches
+package approx
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/ameykpatil/github-data-analyzer/domain/sketch"
+	"github.com/ameykpatil/github-data-analyzer/service"
+)
+
+// Commits is the metric counting the pushed commits, the other metrics are the event types
+const Commits = "Commits"
+
+// default sizes of the sketches, i.e. ~0.8% error of distinct counts,
+// frequencies overestimated by at most ~0.13% of the total with ~99.3% confidence & 100 tracked keys
+const (
+	DefaultPrecision = 14
+	DefaultWidth     = 2048
+	DefaultDepth     = 5
+	DefaultK         = 100
+)
+
+// Options to configure the size of the sketches, 0 means the default
+type Options struct {
+	Precision uint8  `json:"precision"`
+	Width     uint32 `json:"width"`
+	Depth     uint32 `json:"depth"`
+	K         int    `json:"k"`
+}
+
+// Summary is the approximate summary of the activity, it can be serialized & merged with the summary of other files
+// Users & Repos estimate the distinct users & repos, EventTypeUsers & EventTypeRepos the same per event type
+// TopUsers & TopRepos track the heaviest users & repos per metric
+type Summary struct {
+	Options        Options                         `json:"options"`
+	Events         uint64                          `json:"events"`
+	Users          *sketch.HyperLogLog             `json:"users"`
+	Repos          *sketch.HyperLogLog             `json:"repos"`
+	EventTypeUsers map[string]*sketch.HyperLogLog  `json:"eventTypeUsers"`
+	EventTypeRepos map[string]*sketch.HyperLogLog  `json:"eventTypeRepos"`
+	TopUsers       map[string]*sketch.HeavyHitters `json:"topUsers"`
+	TopRepos       map[string]*sketch.HeavyHitters `json:"topRepos"`
+}
+
+// Estimate is the estimated value of a metric of a user or a repo
+// the true value is at most Count & at least Count - Error with probability Confidence
+type Estimate struct {
+	ID         string
+	Name       string
+	Count      uint64
+	Error      uint64
+	Confidence float64
+}
+
+// Distinct is the estimated number of distinct users or repos with its relative standard error
+type Distinct struct {
+	Count         float64
+	RelativeError float64
+}
+
+// NewSummary creates an empty summary
+func NewSummary(options Options) (*Summary, error) {
+	if options.Precision == 0 {
+		options.Precision = DefaultPrecision
+	}
+	if options.Width == 0 {
+		options.Width = DefaultWidth
+	}
+	if options.Depth == 0 {
+		options.Depth = DefaultDepth
+	}
+	if options.K == 0 {
+		options.K = DefaultK
+	}
+	if _, err := sketch.NewHeavyHitters(options.K, options.Width, options.Depth); err != nil {
+		return nil, err
+	}
+	users, err := sketch.NewHyperLogLog(options.Precision)
+	if err != nil {
+		return nil, err
+	}
+	repos, _ := sketch.NewHyperLogLog(options.Precision)
+
+	return &Summary{
+		Options:        options,
+		Users:          users,
+		Repos:          repos,
+		EventTypeUsers: map[string]*sketch.HyperLogLog{},
+		EventTypeRepos: map[string]*sketch.HyperLogLog{},
+		TopUsers:       map[string]*sketch.HeavyHitters{},
+		TopRepos:       map[string]*sketch.HeavyHitters{},
+	}, nil
+}
+
+// Summarize creates the summary of the events already loaded in memory, the events are added in order of id so that the result is deterministic
+// SummarizeFiles should be used to summarize the data files in bounded memory
+func Summarize(eventHandler service.EventHandler, options Options) (*Summary, error) {
+	summary, err := NewSummary(options)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(eventHandler.Events))
+	for id := range eventHandler.Events {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		summary.Add(eventHandler.Events[id])
+	}
+	return summary, nil
+}
+
+// Add adds the event to the summary
+func (s *Summary) Add(event *service.Event) {
+	var userID, userName, repoID, repoName string
+	if event.Actor != nil {
+		userID, userName = event.Actor.ID, event.Actor.Username
+	}
+	if event.Repo != nil {
+		repoID, repoName = event.Repo.ID, event.Repo.Name
+	}
+	s.add(event.Type, userID, userName, repoID, repoName, uint64(len(event.Commits)))
+}
+
+// add adds an event of the type with its number of commits, an empty id means the user or the repo is unknown
+func (s *Summary) add(eventType, userID, userName, repoID, repoName string, commits uint64) {
+	s.Events++
+	if userID != "" {
+		s.Users.Add(userID)
+		s.hyperLogLog(s.EventTypeUsers, eventType).Add(userID)
+		s.heavyHitters(s.TopUsers, eventType).Add(userID, userName, 1)
+		if commits > 0 {
+			s.heavyHitters(s.TopUsers, Commits).Add(userID, userName, commits)
+		}
+	}
+	if repoID != "" {
+		s.Repos.Add(repoID)
+		s.hyperLogLog(s.EventTypeRepos, eventType).Add(repoID)
+		s.heavyHitters(s.TopRepos, eventType).Add(repoID, repoName, 1)
+		if commits > 0 {
+			s.heavyHitters(s.TopRepos, Commits).Add(repoID, repoName, commits)
+		}
+	}
+}
+
+// Merge merges the other summary into this one, both should have the same options
+func (s *Summary) Merge(other *Summary) error {
+	if s.Options != other.Options {
+		return errors.New("cannot merge summaries with different options")
+	}
+	s.Events += other.Events
+	if err := s.Users.Merge(other.Users); err != nil {
+		return err
+	}
+	if err := s.Repos.Merge(other.Repos); err != nil {
+		return err
+	}
+	for eventType, hll := range other.EventTypeUsers {
+		if err := s.hyperLogLog(s.EventTypeUsers, eventType).Merge(hll); err != nil {
+			return err
+		}
+	}
+	for eventType, hll := range other.EventTypeRepos {
+		if err := s.hyperLogLog(s.EventTypeRepos, eventType).Merge(hll); err != nil {
+			return err
+		}
+	}
+	for metric, hh := range other.TopUsers {
+		if err := s.heavyHitters(s.TopUsers, metric).Merge(hh); err != nil {
+			return err
+		}
+	}
+	for metric, hh := range other.TopRepos {
+		if err := s.heavyHitters(s.TopRepos, metric).Merge(hh); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetTopUsers returns the estimated top users by the metric (Commits or an event type) based on provided limit & offset
+// only the K heaviest users are tracked, so limit 0 returns at most K users
+func (s *Summary) GetTopUsers(metric string, limit, offset uint32) []Estimate {
+	return estimates(s.TopUsers[metric], limit, offset)
+}
+
+// GetTopRepos returns the estimated top repos by the metric (Commits or an event type) based on provided limit & offset
+// only the K heaviest repos are tracked, so limit 0 returns at most K repos
+func (s *Summary) GetTopRepos(metric string, limit, offset uint32) []Estimate {
+	return estimates(s.TopRepos[metric], limit, offset)
+}
+
+// GetDistinctUsers returns the estimated number of distinct users with the event type, "" for any event type
+func (s *Summary) GetDistinctUsers(eventType string) Distinct {
+	return distinct(s.Users, s.EventTypeUsers, eventType)
+}
+
+// GetDistinctRepos returns the estimated number of distinct repos with the event type, "" for any event type
+func (s *Summary) GetDistinctRepos(eventType string) Distinct {
+	return distinct(s.Repos, s.EventTypeRepos, eventType)
+}
+
+// Write writes the summary in json format
+func (s *Summary) Write(w io.Writer) error {
+	return json.NewEncoder(w).Encode(s)
+}
+
+// Read reads a summary written in json format
+func Read(r io.Reader) (*Summary, error) {
+	summary := &Summary{}
+	if err := json.NewDecoder(r).Decode(summary); err != nil {
+		return nil, err
+	}
+	if err := summary.validate(); err != nil {
+		return nil, fmt.Errorf("invalid summary: %v", err)
+	}
+	return summary, nil
+}
+
+// validate checks that a decoded summary has all its sketches & that they are sized by its options,
+// so that it can be merged or extended without a panic
+func (s *Summary) validate() error {
+	empty, err := NewSummary(s.Options)
+	if err != nil {
+		return err
+	}
+	if empty.Options != s.Options {
+		return errors.New("sizes of the sketches are missing")
+	}
+	if s.EventTypeUsers == nil || s.EventTypeRepos == nil || s.TopUsers == nil || s.TopRepos == nil {
+		return errors.New("sketches are missing")
+	}
+
+	hlls := []*sketch.HyperLogLog{s.Users, s.Repos}
+	for _, hll := range s.EventTypeUsers {
+		hlls = append(hlls, hll)
+	}
+	for _, hll := range s.EventTypeRepos {
+		hlls = append(hlls, hll)
+	}
+	for _, hll := range hlls {
+		if hll == nil {
+			return errors.New("sketches are missing")
+		}
+		if err := hll.Validate(); err != nil {
+			return err
+		}
+		if hll.Precision != s.Options.Precision {
+			return errors.New("precision does not match the options")
+		}
+	}
+
+	trackers := make([]*sketch.HeavyHitters, 0, len(s.TopUsers)+len(s.TopRepos))
+	for _, hh := range s.TopUsers {
+		trackers = append(trackers, hh)
+	}
+	for _, hh := range s.TopRepos {
+		trackers = append(trackers, hh)
+	}
+	for _, hh := range trackers {
+		if hh == nil {
+			return errors.New("sketches are missing")
+		}
+		if err := hh.Validate(); err != nil {
+			return err
+		}
+		if hh.K != s.Options.K || hh.Sketch.Width != s.Options.Width || hh.Sketch.Depth != s.Options.Depth {
+			return errors.New("k, width or depth does not match the options")
+		}
+	}
+	return nil
+}
+
+// hyperLogLog returns the HyperLogLog of the key, an empty one is created if missing
+func (s *Summary) hyperLogLog(hlls map[string]*sketch.HyperLogLog, key string) *sketch.HyperLogLog {
+	hll, ok := hlls[key]
+	if !ok {
+		hll, _ = sketch.NewHyperLogLog(s.Options.Precision)
+		hlls[key] = hll
+	}
+	return hll
+}
+
+// heavyHitters returns the heavy hitters tracker of the metric, an empty one is created if missing
+func (s *Summary) heavyHitters(trackers map[string]*sketch.HeavyHitters, metric string) *sketch.HeavyHitters {
+	hh, ok := trackers[metric]
+	if !ok {
+		hh, _ = sketch.NewHeavyHitters(s.Options.K, s.Options.Width, s.Options.Depth)
+		trackers[metric] = hh
+	}
+	return hh
+}
+
+// estimates returns the top estimates of the tracker, no estimates if there is no tracker
+func estimates(hh *sketch.HeavyHitters, limit, offset uint32) []Estimate {
+	result := []Estimate{}
+	if hh == nil {
+		return result
+	}
+	for _, item := range hh.Top(int(limit), int(offset)) {
+		result = append(result, Estimate{
+			ID:         item.Key,
+			Name:       item.Label,
+			Count:      item.Count,
+			Error:      hh.Sketch.ErrorBound(),
+			Confidence: hh.Sketch.Confidence(),
+		})
+	}
+	return result
+}
+
+// distinct returns the estimated distinct count of all or of the event type
+func distinct(all *sketch.HyperLogLog, eventTypes map[string]*sketch.HyperLogLog, eventType string) Distinct {
+	hll := all
+	if eventType != "" {
+		if hll = eventTypes[eventType]; hll == nil {
+			return Distinct{}
+		}
+	}
+	return Distinct{Count: hll.Count(), RelativeError: hll.RelativeError()}
+}
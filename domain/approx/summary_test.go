@@ -0,0 +1,107 @@
+package approx
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ameykpatil/github-data-analyzer/db/entities"
+	"github.com/ameykpatil/github-data-analyzer/service"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	actor1 = entities.Actor{ID: "111", Username: "Actor1"}
+	actor2 = entities.Actor{ID: "112", Username: "Actor2"}
+	repo1  = entities.Repo{ID: "441", Name: "Actor1/Repo1"}
+	repo2  = entities.Repo{ID: "442", Name: "Actor2/Repo2"}
+	commit = entities.Commit{Sha: "221", Message: "Message 1", EventID: "331"}
+)
+
+func events() map[string]*service.Event {
+	return map[string]*service.Event{
+		"331": {ID: "331", Type: "PushEvent", Actor: &actor1, Repo: &repo1, Commits: []entities.Commit{commit, commit}},
+		"332": {ID: "332", Type: "PushEvent", Actor: &actor2, Repo: &repo1, Commits: []entities.Commit{commit}},
+		"333": {ID: "333", Type: "WatchEvent", Actor: &actor2, Repo: &repo1},
+		"334": {ID: "334", Type: "WatchEvent", Actor: &actor2, Repo: &repo2},
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	summary, err := Summarize(service.EventHandler{Events: events()}, Options{})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(4), summary.Events)
+
+	users := summary.GetTopUsers(Commits, 0, 0)
+	assert.Len(t, users, 2)
+	assert.Equal(t, "111", users[0].ID)
+	assert.Equal(t, "Actor1", users[0].Name)
+	assert.Equal(t, uint64(2), users[0].Count)
+	assert.Equal(t, uint64(1), users[0].Error)
+	assert.InDelta(t, 0.993, users[0].Confidence, 0.001)
+
+	repos := summary.GetTopRepos("WatchEvent", 1, 0)
+	assert.Equal(t, []Estimate{{ID: "441", Name: "Actor1/Repo1", Count: 1, Error: 1, Confidence: repos[0].Confidence}}, repos)
+	assert.Empty(t, summary.GetTopRepos("ForkEvent", 0, 0))
+
+	assert.InDelta(t, 2, summary.GetDistinctUsers("").Count, 0.01)
+	assert.InDelta(t, 1, summary.GetDistinctUsers("WatchEvent").Count, 0.01)
+	assert.InDelta(t, 2, summary.GetDistinctRepos("WatchEvent").Count, 0.01)
+	assert.Equal(t, Distinct{}, summary.GetDistinctRepos("ForkEvent"))
+}
+
+func TestMerge(t *testing.T) {
+	whole, _ := Summarize(service.EventHandler{Events: events()}, Options{})
+
+	// summaries of two parts written & read back before merging
+	first, second := events(), events()
+	delete(first, "333")
+	delete(first, "334")
+	delete(second, "331")
+	delete(second, "332")
+	merged, _ := Summarize(service.EventHandler{Events: first}, Options{})
+	part, _ := Summarize(service.EventHandler{Events: second}, Options{})
+
+	var buffer bytes.Buffer
+	assert.NoError(t, part.Write(&buffer))
+	read, err := Read(&buffer)
+	assert.NoError(t, err)
+	assert.NoError(t, merged.Merge(read))
+
+	assert.Equal(t, whole.Events, merged.Events)
+	assert.Equal(t, whole.GetTopUsers(Commits, 0, 0), merged.GetTopUsers(Commits, 0, 0))
+	assert.Equal(t, whole.GetTopRepos("WatchEvent", 0, 0), merged.GetTopRepos("WatchEvent", 0, 0))
+	assert.Equal(t, whole.GetDistinctUsers("").Count, merged.GetDistinctUsers("").Count)
+
+	other, _ := NewSummary(Options{K: 5})
+	assert.Error(t, merged.Merge(other))
+	_, err = Read(bytes.NewBufferString("{}"))
+	assert.Error(t, err)
+}
+
+func TestReadInvalid(t *testing.T) {
+	summary, _ := Summarize(service.EventHandler{Events: events()}, Options{})
+	var buffer bytes.Buffer
+	assert.NoError(t, summary.Write(&buffer))
+	written := buffer.String()
+
+	// the sketches are truncated or sized differently than the options
+	for name, corrupt := range map[string]func(s *Summary){
+		"registers": func(s *Summary) { s.Users.Registers = s.Users.Registers[:10] },
+		"precision": func(s *Summary) { s.EventTypeUsers["PushEvent"].Precision = 12 },
+		"rows":      func(s *Summary) { s.TopUsers[Commits].Sketch.Counts = s.TopUsers[Commits].Sketch.Counts[:1] },
+		"counters":  func(s *Summary) { s.TopRepos[Commits].Sketch.Counts[0] = nil },
+		"width":     func(s *Summary) { s.TopRepos[Commits].Sketch.Width = 10 },
+		"k":         func(s *Summary) { s.TopRepos[Commits].K = 1 },
+		"items":     func(s *Summary) { s.TopRepos[Commits].Items = nil },
+		"trackers":  func(s *Summary) { s.TopUsers = nil },
+		"options":   func(s *Summary) { s.Options.Width = 0 },
+	} {
+		read, err := Read(bytes.NewBufferString(written))
+		assert.NoError(t, err, name)
+		corrupt(read)
+		buffer.Reset()
+		assert.NoError(t, read.Write(&buffer), name)
+		_, err = Read(&buffer)
+		assert.Error(t, err, name)
+	}
+}
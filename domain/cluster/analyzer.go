@@ -0,0 +1,168 @@
+package cluster
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ameykpatil/github-data-analyzer/domain/repo"
+	"github.com/ameykpatil/github-data-analyzer/domain/topk"
+	"github.com/ameykpatil/github-data-analyzer/service"
+)
+
+// defaults of the options
+const (
+	DefaultMaxK          = 8
+	DefaultSeed          = 1
+	defaultMaxIterations = 100
+)
+
+// Options configure the clustering
+// K is the number of clusters, 0 chooses the one between 2 & MaxK (up to the number of repos) with the best silhouette score
+// both are at most MaxClusters & K is at most the number of repos clustered
+// only the repos with at least MinEvents events are clustered & Representatives is the number of repos per cluster, 0 lists all
+type Options struct {
+	K               int
+	MaxK            int
+	MinEvents       int
+	Seed            int64
+	Representatives int
+}
+
+// Analyzer encapsulates functionality of clustering the repos by activity profile
+type Analyzer struct {
+	repoAnalyzer *repo.Analyzer
+}
+
+// NewAnalyzer creates a new instance of cluster Analyzer
+func NewAnalyzer(repoAnalyzer *repo.Analyzer) *Analyzer {
+	return &Analyzer{
+		repoAnalyzer: repoAnalyzer,
+	}
+}
+
+// GetRepoClusters clusters the repos by their normalized event type vector i.e. the share of each event type
+func (ca *Analyzer) GetRepoClusters(options Options) (Result, error) {
+	if options.K < 0 || (options.K == 0 && options.MaxK < 2) {
+		return Result{}, errors.New("number of clusters should be at least 2 or chosen up to max k of at least 2")
+	}
+	if options.K > MaxClusters || options.MaxK > MaxClusters {
+		return Result{}, fmt.Errorf("number of clusters & max k should be at most %d", MaxClusters)
+	}
+
+	// the repos are in the order of id, so the result is deterministic
+	repos := []repo.Repo{}
+	for _, r := range ca.repoAnalyzer.GetRepos() {
+		if events(r) >= options.MinEvents && events(r) > 0 {
+			repos = append(repos, r)
+		}
+	}
+	eventTypes := dimensions(repos)
+	points := make([][]float64, len(repos))
+	for i, r := range repos {
+		points[i] = vector(r, eventTypes)
+	}
+
+	if options.K > len(points) {
+		return Result{}, fmt.Errorf("number of clusters %d is more than the number of repos %d with at least %d events", options.K, len(points), options.MinEvents)
+	}
+	if options.K == 0 && len(points) < 2 {
+		return Result{}, fmt.Errorf("at least 2 repos are needed to choose the number of clusters, not %d", len(points))
+	}
+
+	result := Result{Repos: len(repos)}
+	var assignments []int
+	var centroids [][]float64
+	if options.K > 0 {
+		var err error
+		assignments, centroids, err = KMeans(points, options.K, options.Seed, defaultMaxIterations)
+		if err != nil {
+			return Result{}, err
+		}
+		result.Scores = []Score{{K: len(centroids), Silhouette: Silhouette(points, assignments, len(centroids), options.Seed)}}
+	} else {
+		best := -2.0
+		for k := 2; k <= options.MaxK && k <= len(points); k++ {
+			a, c, err := KMeans(points, k, options.Seed, defaultMaxIterations)
+			if err != nil {
+				return Result{}, err
+			}
+			silhouette := Silhouette(points, a, len(c), options.Seed)
+			result.Scores = append(result.Scores, Score{K: len(c), Silhouette: silhouette})
+			if silhouette > best {
+				best, assignments, centroids = silhouette, a, c
+			}
+		}
+	}
+	result.K = len(centroids)
+
+	result.Clusters = clusters(repos, points, assignments, centroids, eventTypes, options.Representatives)
+	return result, nil
+}
+
+// clusters creates the clusters from the assignments of the repos, ordered by size
+func clusters(repos []repo.Repo, points [][]float64, assignments []int, centroids [][]float64, eventTypes []string, representatives int) []Cluster {
+	all := make([]Cluster, 0, len(centroids))
+	for c, centroid := range centroids {
+		cluster := Cluster{Profile: []Share{}}
+		for d, eventType := range eventTypes {
+			cluster.Profile = append(cluster.Profile, Share{EventType: eventType, Share: centroid[d]})
+		}
+
+		members := distanceRanking{}
+		for i := range repos {
+			if assignments[i] == c {
+				members.indices = append(members.indices, i)
+				members.ids = append(members.ids, repos[i].ID)
+				members.distances = append(members.distances, squaredDistance(points[i], centroid))
+			}
+		}
+		cluster.Size = len(members.ids)
+		if cluster.Size == 0 {
+			continue
+		}
+		cluster.Share = 100 * float64(cluster.Size) / float64(len(repos))
+		for _, i := range topk.Select(members, representatives, 0) {
+			cluster.Representatives = append(cluster.Representatives, repos[members.indices[i]])
+		}
+		all = append(all, cluster)
+	}
+
+	ordered := make([]Cluster, 0, len(all))
+	for _, i := range topk.Select(sizeRanking(all), 0, 0) {
+		ordered = append(ordered, all[i])
+	}
+	return ordered
+}
+
+// dimensions returns the event types present in the repos, in the order of the GitHub event types
+func dimensions(repos []repo.Repo) []string {
+	eventTypes := []string{}
+	for _, eventType := range service.EventTypes {
+		for _, r := range repos {
+			if r.EventTypeCount[eventType] > 0 {
+				eventTypes = append(eventTypes, eventType)
+				break
+			}
+		}
+	}
+	return eventTypes
+}
+
+// vector returns the share of each event type in the events of the repo
+func vector(r repo.Repo, eventTypes []string) []float64 {
+	total := events(r)
+	v := make([]float64, len(eventTypes))
+	for d, eventType := range eventTypes {
+		v[d] = float64(r.EventTypeCount[eventType]) / float64(total)
+	}
+	return v
+}
+
+// events returns the total number of events of the repo
+func events(r repo.Repo) int {
+	total := 0
+	for _, count := range r.EventTypeCount {
+		total += count
+	}
+	return total
+}
@@ -0,0 +1,92 @@
+package cluster
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/ameykpatil/github-data-analyzer/db/entities"
+	"github.com/ameykpatil/github-data-analyzer/domain/repo"
+	"github.com/ameykpatil/github-data-analyzer/service"
+	"github.com/stretchr/testify/assert"
+)
+
+// newRepoAnalyzer creates a repo analyzer of the repos with the given event types
+func newRepoAnalyzer(repoEventTypes map[string][]string) *repo.Analyzer {
+	events := map[string]*service.Event{}
+	actor := &entities.Actor{ID: "111", Username: "Actor1"}
+	for id, eventTypes := range repoEventTypes {
+		r := &entities.Repo{ID: id, Name: "Owner/" + id}
+		for _, eventType := range eventTypes {
+			eventID := strconv.Itoa(len(events))
+			events[eventID] = &service.Event{ID: eventID, Type: eventType, Actor: actor, Repo: r}
+		}
+	}
+	return repo.NewAnalyzer(service.EventHandler{DataStore: nil, Events: events}, repo.Options{})
+}
+
+func TestGetRepoClusters(t *testing.T) {
+	repoAnalyzer := newRepoAnalyzer(map[string][]string{
+		"1": {"WatchEvent", "WatchEvent", "ForkEvent"},
+		"2": {"WatchEvent", "WatchEvent"},
+		"3": {"WatchEvent", "WatchEvent", "WatchEvent"},
+		"4": {"PushEvent", "PushEvent", "CreateEvent"},
+		"5": {"PushEvent", "PushEvent"},
+		"6": {"WatchEvent"},
+	})
+	analyzer := NewAnalyzer(repoAnalyzer)
+
+	result, err := analyzer.GetRepoClusters(Options{K: 2, Seed: DefaultSeed, MinEvents: 2, Representatives: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, result.K)
+	assert.Equal(t, 5, result.Repos)
+	assert.Len(t, result.Clusters, 2)
+
+	// the biggest cluster is the one of the watched repos & its nearest repo is the one with only watch events
+	assert.Equal(t, 3, result.Clusters[0].Size)
+	assert.Equal(t, 60.0, result.Clusters[0].Share)
+	assert.Equal(t, []string{"2"}, ids(result.Clusters[0].Representatives))
+	assert.Equal(t, 2, result.Clusters[1].Size)
+
+	// the profile has a share per event type present in the repos
+	assert.Equal(t, []string{"CreateEvent", "ForkEvent", "PushEvent", "WatchEvent"}, eventTypes(result.Clusters[1].Profile))
+	assert.InDelta(t, 5.0/6, result.Clusters[1].Profile[2].Share, 1e-9)
+
+	// k is chosen by the silhouette score
+	result, err = analyzer.GetRepoClusters(Options{MaxK: 4, Seed: DefaultSeed, MinEvents: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, result.K)
+	assert.Len(t, result.Scores, 3)
+	assert.Len(t, result.Clusters[0].Representatives, 3)
+
+	// max k more than the repos is capped by them
+	result, err = analyzer.GetRepoClusters(Options{MaxK: 10, Seed: DefaultSeed, MinEvents: 2})
+	assert.NoError(t, err)
+	assert.Len(t, result.Scores, 4)
+	assert.Equal(t, len(result.Clusters), result.K)
+
+	_, err = analyzer.GetRepoClusters(Options{MaxK: 1})
+	assert.Error(t, err)
+	// more clusters than the repos or than the bound
+	_, err = analyzer.GetRepoClusters(Options{K: 6, MinEvents: 2})
+	assert.Error(t, err)
+	_, err = analyzer.GetRepoClusters(Options{MaxK: MaxClusters + 1})
+	assert.Error(t, err)
+	_, err = analyzer.GetRepoClusters(Options{MaxK: 4, MinEvents: 10})
+	assert.Error(t, err)
+}
+
+func ids(repos []repo.Repo) []string {
+	result := []string{}
+	for _, r := range repos {
+		result = append(result, r.ID)
+	}
+	return result
+}
+
+func eventTypes(profile []Share) []string {
+	result := []string{}
+	for _, share := range profile {
+		result = append(result, share.EventType)
+	}
+	return result
+}
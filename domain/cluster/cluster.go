@@ -0,0 +1,77 @@
+package cluster
+
+import "github.com/ameykpatil/github-data-analyzer/domain/repo"
+
+// Cluster encapsulates a group of repos with similar activity profiles
+// Profile is the centroid i.e. the mean share of each event type & Representatives are the repos nearest to it
+type Cluster struct {
+	Size            int
+	Share           float64
+	Profile         []Share
+	Representatives []repo.Repo
+}
+
+// Share is the share of an event type in the activity profile of a cluster
+type Share struct {
+	EventType string
+	Share     float64
+}
+
+// Score is the silhouette score of the clustering into K clusters
+type Score struct {
+	K          int
+	Silhouette float64
+}
+
+// Result encapsulates the clusters of the repos, ordered by size
+// Scores are the silhouette scores of every tried number of clusters & K is the chosen one
+type Result struct {
+	K        int
+	Repos    int
+	Scores   []Score
+	Clusters []Cluster
+}
+
+// distanceRanking ranks the points nearest to a centroid first (topk Interface)
+// indices are the indices of the points among all the points
+type distanceRanking struct {
+	indices   []int
+	ids       []string
+	distances []float64
+}
+
+// Len is the number of points to rank
+func (r distanceRanking) Len() int {
+	return len(r.ids)
+}
+
+// Less reports whether the point i is nearer than the point j
+func (r distanceRanking) Less(i, j int) bool {
+	return r.distances[i] < r.distances[j]
+}
+
+// ID is used to break the ties between points
+func (r distanceRanking) ID(i int) string {
+	return r.ids[i]
+}
+
+// sizeRanking ranks the biggest clusters first (topk Interface)
+type sizeRanking []Cluster
+
+// Len is the number of clusters to rank
+func (r sizeRanking) Len() int {
+	return len(r)
+}
+
+// Less reports whether the cluster i is bigger than the cluster j
+func (r sizeRanking) Less(i, j int) bool {
+	return r[i].Size > r[j].Size
+}
+
+// ID is used to break the ties between clusters, i.e. by the id of the nearest repo
+func (r sizeRanking) ID(i int) string {
+	if len(r[i].Representatives) == 0 {
+		return ""
+	}
+	return r[i].Representatives[0].ID
+}
@@ -0,0 +1,181 @@
+// Package cluster groups the repos with similar activity profiles using k-means
+package cluster
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+)
+
+// silhouetteSampleSize is the maximum number of points the silhouette score is computed on, as it is quadratic
+const silhouetteSampleSize = 2000
+
+// MaxClusters is the maximum number of clusters, every iteration is linear in it & more clusters are not readable anyway
+const MaxClusters = 100
+
+// KMeans clusters the points into k clusters & returns the cluster of each point & the centroids
+// the centroids are initialised with k-means++ using the seed, so the result is deterministic for the same input
+// it stops when no point changes its cluster or after maxIterations
+// k should be between 1 & MaxClusters & not more than the number of points
+func KMeans(points [][]float64, k int, seed int64, maxIterations int) ([]int, [][]float64, error) {
+	if k <= 0 || k > MaxClusters {
+		return nil, nil, fmt.Errorf("number of clusters should be between 1 & %d, not %d", MaxClusters, k)
+	}
+	if k > len(points) {
+		return nil, nil, fmt.Errorf("number of clusters %d is more than the number of points %d", k, len(points))
+	}
+
+	random := rand.New(rand.NewSource(seed))
+	centroids := initCentroids(points, k, random)
+	assignments := make([]int, len(points))
+	for i := range assignments {
+		assignments[i] = -1
+	}
+
+	for iteration := 0; iteration < maxIterations; iteration++ {
+		changed := false
+		for i, point := range points {
+			if c := nearest(point, centroids); c != assignments[i] {
+				assignments[i], changed = c, true
+			}
+		}
+		if !changed {
+			break
+		}
+		centroids = updateCentroids(points, assignments, centroids)
+	}
+
+	return assignments, centroids, nil
+}
+
+// initCentroids picks the first centroid at random & every next one with probability proportional to
+// the squared distance from the nearest centroid picked so far (k-means++)
+func initCentroids(points [][]float64, k int, random *rand.Rand) [][]float64 {
+	centroids := [][]float64{clone(points[random.Intn(len(points))])}
+	distances := make([]float64, len(points))
+	for len(centroids) < k {
+		total := 0.0
+		for i, point := range points {
+			distances[i] = squaredDistance(point, centroids[nearest(point, centroids)])
+			total += distances[i]
+		}
+		// all the points are on the centroids already
+		if total == 0 {
+			centroids = append(centroids, clone(points[random.Intn(len(points))]))
+			continue
+		}
+		target, next := random.Float64()*total, len(points)-1
+		for i, distance := range distances {
+			if target < distance {
+				next = i
+				break
+			}
+			target -= distance
+		}
+		centroids = append(centroids, clone(points[next]))
+	}
+	return centroids
+}
+
+// updateCentroids returns the means of the points of each cluster, an empty cluster keeps its centroid
+func updateCentroids(points [][]float64, assignments []int, centroids [][]float64) [][]float64 {
+	sums := make([][]float64, len(centroids))
+	counts := make([]int, len(centroids))
+	for c := range sums {
+		sums[c] = make([]float64, len(centroids[c]))
+	}
+	for i, point := range points {
+		c := assignments[i]
+		counts[c]++
+		for d, value := range point {
+			sums[c][d] += value
+		}
+	}
+
+	updated := make([][]float64, len(centroids))
+	for c := range sums {
+		if counts[c] == 0 {
+			updated[c] = centroids[c]
+			continue
+		}
+		for d := range sums[c] {
+			sums[c][d] /= float64(counts[c])
+		}
+		updated[c] = sums[c]
+	}
+	return updated
+}
+
+// Silhouette returns the mean silhouette score of the clustering, between -1 & 1 where higher means better separated
+// clusters, it is computed on a sample of the points chosen with the seed if there are too many of them
+func Silhouette(points [][]float64, assignments []int, k int, seed int64) float64 {
+	if k < 2 || len(points) < 2 {
+		return 0
+	}
+	sample := make([]int, len(points))
+	for i := range sample {
+		sample[i] = i
+	}
+	if len(sample) > silhouetteSampleSize {
+		random := rand.New(rand.NewSource(seed))
+		random.Shuffle(len(sample), func(i, j int) {
+			sample[i], sample[j] = sample[j], sample[i]
+		})
+		sample = sample[:silhouetteSampleSize]
+	}
+
+	total := 0.0
+	for _, i := range sample {
+		// mean distance to the (sampled) points of each cluster
+		sums := make([]float64, k)
+		counts := make([]int, k)
+		for _, j := range sample {
+			if i == j {
+				continue
+			}
+			sums[assignments[j]] += math.Sqrt(squaredDistance(points[i], points[j]))
+			counts[assignments[j]]++
+		}
+		own := assignments[i]
+		if counts[own] == 0 {
+			// the silhouette of a single point cluster is 0
+			continue
+		}
+		a, b := sums[own]/float64(counts[own]), math.Inf(1)
+		for c := range sums {
+			if c != own && counts[c] > 0 && sums[c]/float64(counts[c]) < b {
+				b = sums[c] / float64(counts[c])
+			}
+		}
+		if math.IsInf(b, 1) || math.Max(a, b) == 0 {
+			continue
+		}
+		total += (b - a) / math.Max(a, b)
+	}
+	return total / float64(len(sample))
+}
+
+// nearest returns the index of the centroid nearest to the point, the first one in case of a tie
+func nearest(point []float64, centroids [][]float64) int {
+	best, bestDistance := 0, math.Inf(1)
+	for c, centroid := range centroids {
+		if distance := squaredDistance(point, centroid); distance < bestDistance {
+			best, bestDistance = c, distance
+		}
+	}
+	return best
+}
+
+// squaredDistance returns the squared euclidean distance between a & b
+func squaredDistance(a, b []float64) float64 {
+	sum := 0.0
+	for d := range a {
+		sum += (a[d] - b[d]) * (a[d] - b[d])
+	}
+	return sum
+}
+
+// clone returns a copy of the vector
+func clone(vector []float64) []float64 {
+	return append([]float64(nil), vector...)
+}
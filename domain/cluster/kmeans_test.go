@@ -0,0 +1,65 @@
+package cluster
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var blobs = [][]float64{
+	{0, 0}, {0.1, 0}, {0, 0.1}, {0.1, 0.1},
+	{5, 5}, {5.1, 5}, {5, 5.1},
+	{10, 0}, {10.1, 0},
+}
+
+func TestKMeans(t *testing.T) {
+	assignments, centroids, err := KMeans(blobs, 3, 1, 100)
+	assert.NoError(t, err)
+	assert.Len(t, centroids, 3)
+
+	// the points of a blob share the cluster & the blobs do not
+	assert.Equal(t, assignments[0], assignments[3])
+	assert.Equal(t, assignments[4], assignments[6])
+	assert.Equal(t, assignments[7], assignments[8])
+	assert.NotEqual(t, assignments[0], assignments[4])
+	assert.NotEqual(t, assignments[0], assignments[7])
+	assert.NotEqual(t, assignments[4], assignments[7])
+	assert.InDeltaSlice(t, []float64{0.05, 0.05}, centroids[assignments[0]], 1e-9)
+
+	// the same seed gives the same clusters
+	again, _, _ := KMeans(blobs, 3, 1, 100)
+	assert.Equal(t, assignments, again)
+}
+
+func TestKMeansEdgeCases(t *testing.T) {
+	assignments, centroids, err := KMeans(blobs[:2], 2, 1, 100)
+	assert.NoError(t, err)
+	assert.Len(t, centroids, 2)
+	assert.NotEqual(t, assignments[0], assignments[1])
+
+	// more clusters than points, no points or too many clusters
+	_, _, err = KMeans(blobs[:2], 5, 1, 100)
+	assert.Error(t, err)
+	_, _, err = KMeans([][]float64{}, 2, 1, 100)
+	assert.Error(t, err)
+	_, _, err = KMeans(blobs, 0, 1, 100)
+	assert.Error(t, err)
+	_, _, err = KMeans(make([][]float64, MaxClusters+1), MaxClusters+1, 1, 100)
+	assert.Error(t, err)
+
+	// identical points
+	assignments, _, err = KMeans([][]float64{{1}, {1}, {1}}, 2, 1, 100)
+	assert.NoError(t, err)
+	assert.Len(t, assignments, 3)
+}
+
+func TestSilhouette(t *testing.T) {
+	good, _, _ := KMeans(blobs, 3, 1, 100)
+	assert.Greater(t, Silhouette(blobs, good, 3, 1), 0.9)
+
+	// mixing the blobs gives a worse score
+	bad := []int{0, 1, 2, 0, 1, 2, 0, 1, 2}
+	assert.Less(t, Silhouette(blobs, bad, 3, 1), 0.0)
+
+	assert.Equal(t, 0.0, Silhouette(blobs, make([]int, len(blobs)), 1, 1))
+}
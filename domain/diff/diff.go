@@ -0,0 +1,143 @@
+// Package diff compares a metric of the users or repos of two datasets & ranks the changes
+package diff
+
+import (
+	"math"
+	"sort"
+
+	"github.com/ameykpatil/github-data-analyzer/domain/topk"
+)
+
+// Status tells whether a user or a repo is in both the datasets or only in one of them
+type Status string
+
+// statuses of a change
+const (
+	Changed Status = "Changed"
+	New     Status = "New"
+	Dropped Status = "Dropped"
+	// FellOut is the status of a rank change of a user or a repo still in the head dataset but no more in its top list
+	FellOut Status = "FellOut"
+)
+
+// Order decides how the changes are ranked
+type Order string
+
+// orders of the changes
+const (
+	// Absolute ranks by the absolute difference of the values
+	Absolute Order = "Absolute"
+	// Relative ranks by the absolute difference relative to the base value, new entrants are not ranked
+	Relative Order = "Relative"
+)
+
+// Value is the value of the metric of a user or a repo
+type Value struct {
+	ID    string
+	Name  string
+	Value float64
+}
+
+// Change encapsulates the change of the metric of a user or a repo between the base & the head dataset
+// Relative is Delta / Base, it is +Inf for new entrants & -1 for dropouts
+type Change struct {
+	ID       string
+	Name     string
+	Base     float64
+	Head     float64
+	Delta    float64
+	Relative float64
+	Status   Status
+}
+
+// Compare aligns the base & head values by id & returns the change of each user or repo, ordered by id
+// the name of the head is used if the user or repo is in both
+func Compare(base, head []Value) []Change {
+	changeMap := make(map[string]*Change)
+	for _, value := range base {
+		changeMap[value.ID] = &Change{ID: value.ID, Name: value.Name, Base: value.Value, Status: Dropped}
+	}
+	for _, value := range head {
+		change, ok := changeMap[value.ID]
+		if !ok {
+			change = &Change{ID: value.ID, Status: New}
+			changeMap[value.ID] = change
+		} else {
+			change.Status = Changed
+		}
+		change.Name = value.Name
+		change.Head = value.Value
+	}
+
+	all := make([]Change, 0, len(changeMap))
+	for _, change := range changeMap {
+		change.Delta = change.Head - change.Base
+		switch {
+		case change.Base != 0:
+			change.Relative = change.Delta / change.Base
+		case change.Head != 0:
+			change.Relative = math.Inf(1)
+		}
+		all = append(all, *change)
+	}
+
+	sort.Slice(all, func(i, j int) bool {
+		return all[i].ID < all[j].ID
+	})
+	return all
+}
+
+// GetTopChanges returns the biggest changes with the given status ("" for any) based on provided order, limit & offset
+// unchanged values are skipped, limit 0 returns all the changes & ties are broken by the id
+func GetTopChanges(changes []Change, status Status, order Order, limit, offset uint32) []Change {
+	all := make([]Change, 0, len(changes))
+	for _, change := range changes {
+		if change.Delta == 0 || (status != "" && change.Status != status) {
+			continue
+		}
+		if order == Relative && math.IsInf(change.Relative, 0) {
+			continue
+		}
+		all = append(all, change)
+	}
+
+	less := func(ci, cj Change) bool {
+		return math.Abs(ci.Delta) > math.Abs(cj.Delta)
+	}
+	if order == Relative {
+		less = func(ci, cj Change) bool {
+			if math.Abs(ci.Relative) == math.Abs(cj.Relative) {
+				return math.Abs(ci.Delta) > math.Abs(cj.Delta)
+			}
+			return math.Abs(ci.Relative) > math.Abs(cj.Relative)
+		}
+	}
+
+	indices := topk.Select(changeRanking{changes: all, less: less}, int(limit), int(offset))
+	top := make([]Change, 0, len(indices))
+	for _, i := range indices {
+		top = append(top, all[i])
+	}
+	return top
+}
+
+// changeRanking ranks the changes using the given sort function (topk Interface)
+type changeRanking struct {
+	changes []Change
+	less    func(ci, cj Change) bool
+}
+
+// Len is the number of changes to rank
+func (r changeRanking) Len() int {
+	return len(r.changes)
+}
+
+// Less reports whether the change i ranks before the change j
+func (r changeRanking) Less(i, j int) bool {
+	return r.less(r.changes[i], r.changes[j])
+}
+
+// ID is used to break the ties between changes
+func (r changeRanking) ID(i int) string {
+	return r.changes[i].ID
+}
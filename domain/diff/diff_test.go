@@ -0,0 +1,117 @@
+package diff
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	base = []Value{
+		{ID: "1", Name: "user1", Value: 10},
+		{ID: "2", Name: "user2", Value: 4},
+		{ID: "3", Name: "user3", Value: 5},
+		{ID: "4", Name: "user4", Value: 7},
+	}
+	head = []Value{
+		{ID: "1", Name: "user1-renamed", Value: 20},
+		{ID: "2", Name: "user2", Value: 12},
+		{ID: "3", Name: "user3", Value: 5},
+		{ID: "5", Name: "user5", Value: 3},
+	}
+)
+
+func TestCompare(t *testing.T) {
+	changes := Compare(base, head)
+	assert.Equal(t, []Change{
+		{ID: "1", Name: "user1-renamed", Base: 10, Head: 20, Delta: 10, Relative: 1, Status: Changed},
+		{ID: "2", Name: "user2", Base: 4, Head: 12, Delta: 8, Relative: 2, Status: Changed},
+		{ID: "3", Name: "user3", Base: 5, Head: 5, Delta: 0, Relative: 0, Status: Changed},
+		{ID: "4", Name: "user4", Base: 7, Head: 0, Delta: -7, Relative: -1, Status: Dropped},
+		{ID: "5", Name: "user5", Base: 0, Head: 3, Delta: 3, Relative: math.Inf(1), Status: New},
+	}, changes)
+}
+
+func TestGetTopChanges(t *testing.T) {
+	changes := Compare(base, head)
+	ids := func(changes []Change) []string {
+		result := []string{}
+		for _, change := range changes {
+			result = append(result, change.ID)
+		}
+		return result
+	}
+
+	tests := []struct {
+		name   string
+		status Status
+		order  Order
+		limit  uint32
+		offset uint32
+		exp    []string
+	}{
+		{
+			name:  "absolute skips unchanged",
+			order: Absolute,
+			exp:   []string{"1", "2", "4", "5"},
+		},
+		{
+			name:  "relative skips new entrants",
+			order: Relative,
+			exp:   []string{"2", "1", "4"},
+		},
+		{
+			name:   "changed only",
+			status: Changed,
+			order:  Absolute,
+			limit:  1,
+			exp:    []string{"1"},
+		},
+		{
+			name:   "new entrants",
+			status: New,
+			order:  Absolute,
+			exp:    []string{"5"},
+		},
+		{
+			name:   "offset",
+			order:  Absolute,
+			limit:  2,
+			offset: 3,
+			exp:    []string{"5"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.exp, ids(GetTopChanges(changes, tt.status, tt.order, tt.limit, tt.offset)))
+		})
+	}
+}
+
+func TestCompareRanks(t *testing.T) {
+	baseRanking := []Item{{ID: "1"}, {ID: "2"}, {ID: "3"}, {ID: "4"}}
+	headRanking := []Item{{ID: "2"}, {ID: "5"}, {ID: "1"}, {ID: "3"}}
+
+	assert.Equal(t, []RankChange{
+		{ID: "2", BaseRank: 2, HeadRank: 1, Change: 1, Status: Changed},
+		{ID: "5", BaseRank: 0, HeadRank: 2, Change: 0, Status: New},
+		{ID: "1", BaseRank: 1, HeadRank: 3, Change: -2, Status: Changed},
+		{ID: "3", BaseRank: 3, HeadRank: 4, Change: -1, Status: FellOut},
+	}, CompareRanks(baseRanking, headRanking, 3))
+
+	// limit 0 compares all the items, only the ones out of the head dataset are dropped
+	assert.Equal(t, []RankChange{
+		{ID: "2", BaseRank: 2, HeadRank: 1, Change: 1, Status: Changed},
+		{ID: "5", BaseRank: 0, HeadRank: 2, Change: 0, Status: New},
+		{ID: "1", BaseRank: 1, HeadRank: 3, Change: -2, Status: Changed},
+		{ID: "3", BaseRank: 3, HeadRank: 4, Change: -1, Status: Changed},
+		{ID: "4", BaseRank: 4, HeadRank: 0, Change: 0, Status: Dropped},
+	}, CompareRanks(baseRanking, headRanking, 0))
+
+	// dropped out of the dataset
+	assert.Equal(t, []RankChange{
+		{ID: "2", BaseRank: 2, HeadRank: 1, Change: 1, Status: Changed},
+		{ID: "1", BaseRank: 1, HeadRank: 0, Change: 0, Status: Dropped},
+	}, CompareRanks(baseRanking, headRanking[:1], 2))
+}
@@ -0,0 +1,65 @@
+package diff
+
+// Item is a user or a repo in a ranking
+type Item struct {
+	ID   string
+	Name string
+}
+
+// RankChange encapsulates the change of the position of a user or a repo in a top list
+// a rank of 0 means the user or repo is not in that dataset, Change is positive when it moved up
+type RankChange struct {
+	ID       string
+	Name     string
+	BaseRank int
+	HeadRank int
+	Change   int
+	Status   Status
+}
+
+// CompareRanks compares the top limit items of the base & head rankings (all the items in rank order), limit 0 compares all
+// it returns the items in the head top list with their base rank followed by the items of the base top list
+// which fell out of the head top list or dropped out of the head dataset
+func CompareRanks(base, head []Item, limit int) []RankChange {
+	if limit <= 0 {
+		limit = len(base)
+		if len(head) > limit {
+			limit = len(head)
+		}
+	}
+	baseRanks := ranks(base)
+	headRanks := ranks(head)
+
+	changes := []RankChange{}
+	for i := 0; i < limit && i < len(head); i++ {
+		change := RankChange{ID: head[i].ID, Name: head[i].Name, BaseRank: baseRanks[head[i].ID], HeadRank: i + 1, Status: Changed}
+		if change.BaseRank == 0 {
+			change.Status = New
+		} else {
+			change.Change = change.BaseRank - change.HeadRank
+		}
+		changes = append(changes, change)
+	}
+	for i := 0; i < limit && i < len(base); i++ {
+		headRank := headRanks[base[i].ID]
+		if headRank > 0 && headRank <= limit {
+			continue
+		}
+		change := RankChange{ID: base[i].ID, Name: base[i].Name, BaseRank: i + 1, HeadRank: headRank, Status: Dropped}
+		if headRank > 0 {
+			change.Change = change.BaseRank - change.HeadRank
+			change.Status = FellOut
+		}
+		changes = append(changes, change)
+	}
+	return changes
+}
+
+// ranks returns the rank (1 based) of the items by id
+func ranks(items []Item) map[string]int {
+	rankMap := make(map[string]int, len(items))
+	for i, item := range items {
+		rankMap[item.ID] = i + 1
+	}
+	return rankMap
+}
@@ -0,0 +1,97 @@
+// Package distribution computes summary statistics & log-binned histograms of activity metrics
+package distribution
+
+import (
+	"math"
+	"sort"
+)
+
+// Bin is a histogram bin counting the values in [Lower, Upper)
+type Bin struct {
+	Lower float64 `json:"lower"`
+	Upper float64 `json:"upper"`
+	Count int     `json:"count"`
+}
+
+// Distribution encapsulates the summary statistics & the histogram of a metric
+// percentiles use the nearest-rank method & the bins of counts are powers of 2 i.e. [0,1), [1,2), [2,4), [4,8) ...
+// the bins of ratios up to 1 (e.g. Gini) are linear i.e. [0,0.1), [0.1,0.2) ... [0.9,1), [1,1.1)
+// & the ones of other fractional values (e.g. Influence) are powers of 2 from 1/16 i.e. [0,0.0625), [0.0625,0.125) ...
+type Distribution struct {
+	Count     int     `json:"count"`
+	Sum       float64 `json:"sum"`
+	Mean      float64 `json:"mean"`
+	Median    float64 `json:"median"`
+	P90       float64 `json:"p90"`
+	P99       float64 `json:"p99"`
+	Max       float64 `json:"max"`
+	Histogram []Bin   `json:"histogram"`
+}
+
+// Compute returns the distribution of the non-negative values
+func Compute(values []float64) Distribution {
+	d := Distribution{Count: len(values), Histogram: []Bin{}}
+	if len(values) == 0 {
+		return d
+	}
+
+	sorted := append([]float64{}, values...)
+	sort.Float64s(sorted)
+	for _, value := range sorted {
+		d.Sum += value
+	}
+	d.Mean = d.Sum / float64(len(sorted))
+	d.Median = percentile(sorted, 50)
+	d.P90 = percentile(sorted, 90)
+	d.P99 = percentile(sorted, 99)
+	d.Max = sorted[len(sorted)-1]
+
+	d.Histogram = bins(sorted)
+	for _, value := range sorted {
+		i := sort.Search(len(d.Histogram), func(i int) bool {
+			return d.Histogram[i].Upper > value
+		})
+		d.Histogram[i].Count++
+	}
+
+	return d
+}
+
+// percentile returns the p-th percentile of the sorted values using the nearest-rank method
+func percentile(sorted []float64, p float64) float64 {
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+// bins returns the empty histogram bins of the sorted values up to the bin of the maximum value
+func bins(sorted []float64) []Bin {
+	max := sorted[len(sorted)-1]
+	fractional := false
+	for _, value := range sorted {
+		if value != math.Trunc(value) {
+			fractional = true
+			break
+		}
+	}
+
+	histogram := []Bin{}
+	if fractional && max <= 1 {
+		for i := 0; float64(i)/10 <= max; i++ {
+			histogram = append(histogram, Bin{Lower: float64(i) / 10, Upper: float64(i+1) / 10})
+		}
+		return histogram
+	}
+
+	lowest := 1.0
+	if fractional {
+		lowest = 1.0 / 16
+	}
+	histogram = append(histogram, Bin{Lower: 0, Upper: lowest})
+	for upper := 2 * lowest; upper <= 2*max; upper *= 2 {
+		histogram = append(histogram, Bin{Lower: upper / 2, Upper: upper})
+	}
+	return histogram
+}
@@ -0,0 +1,103 @@
+package distribution
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompute(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []float64
+		exp    Distribution
+	}{
+		{
+			name:   "no values",
+			values: []float64{},
+			exp:    Distribution{Histogram: []Bin{}},
+		},
+		{
+			name:   "zero values",
+			values: []float64{0, 0},
+			exp: Distribution{
+				Count:     2,
+				Histogram: []Bin{{Lower: 0, Upper: 1, Count: 2}},
+			},
+		},
+		{
+			name:   "skewed values",
+			values: []float64{5, 0, 1, 1, 2, 3, 1, 0, 1, 16},
+			exp: Distribution{
+				Count:  10,
+				Sum:    30,
+				Mean:   3,
+				Median: 1,
+				P90:    5,
+				P99:    16,
+				Max:    16,
+				Histogram: []Bin{
+					{Lower: 0, Upper: 1, Count: 2},
+					{Lower: 1, Upper: 2, Count: 4},
+					{Lower: 2, Upper: 4, Count: 2},
+					{Lower: 4, Upper: 8, Count: 1},
+					{Lower: 8, Upper: 16, Count: 0},
+					{Lower: 16, Upper: 32, Count: 1},
+				},
+			},
+		},
+		{
+			name:   "ratios",
+			values: []float64{1, 0.25, 0.5, 0, 1},
+			exp: Distribution{
+				Count:  5,
+				Sum:    2.75,
+				Mean:   0.55,
+				Median: 0.5,
+				P90:    1,
+				P99:    1,
+				Max:    1,
+				Histogram: []Bin{
+					{Lower: 0, Upper: 0.1, Count: 1},
+					{Lower: 0.1, Upper: 0.2, Count: 0},
+					{Lower: 0.2, Upper: 0.3, Count: 1},
+					{Lower: 0.3, Upper: 0.4, Count: 0},
+					{Lower: 0.4, Upper: 0.5, Count: 0},
+					{Lower: 0.5, Upper: 0.6, Count: 1},
+					{Lower: 0.6, Upper: 0.7, Count: 0},
+					{Lower: 0.7, Upper: 0.8, Count: 0},
+					{Lower: 0.8, Upper: 0.9, Count: 0},
+					{Lower: 0.9, Upper: 1, Count: 0},
+					{Lower: 1, Upper: 1.1, Count: 2},
+				},
+			},
+		},
+		{
+			name:   "fractional values",
+			values: []float64{0.1, 0.5, 1.5, 3},
+			exp: Distribution{
+				Count:  4,
+				Sum:    5.1,
+				Mean:   1.275,
+				Median: 0.5,
+				P90:    3,
+				P99:    3,
+				Max:    3,
+				Histogram: []Bin{
+					{Lower: 0, Upper: 0.0625, Count: 0},
+					{Lower: 0.0625, Upper: 0.125, Count: 1},
+					{Lower: 0.125, Upper: 0.25, Count: 0},
+					{Lower: 0.25, Upper: 0.5, Count: 0},
+					{Lower: 0.5, Upper: 1, Count: 1},
+					{Lower: 1, Upper: 2, Count: 1},
+					{Lower: 2, Upper: 4, Count: 1},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.exp, Compute(tt.values))
+		})
+	}
+}
@@ -0,0 +1,128 @@
+package eventtype
+
+import (
+	"github.com/ameykpatil/github-data-analyzer/domain/topk"
+	"github.com/ameykpatil/github-data-analyzer/service"
+)
+
+// KeyFunc returns the key & name of the group to which the event belongs, empty key skips the event
+type KeyFunc func(event *service.Event) (string, string)
+
+// ByOwner groups the events by the owner of the repo
+func ByOwner(event *service.Event) (string, string) {
+	if event.Repo == nil {
+		return "", ""
+	}
+	owner := service.RepoOwner(event.Repo.Name)
+	return owner, owner
+}
+
+// ByUser groups the events by the actor, named by its username
+func ByUser(event *service.Event) (string, string) {
+	if event.Actor == nil {
+		return "", ""
+	}
+	return event.Actor.ID, event.Actor.Username
+}
+
+// byDataset puts every event in the same group
+func byDataset(event *service.Event) (string, string) {
+	return "all", "all"
+}
+
+// Analyzer encapsulates functionality of analyzing the event types
+type Analyzer struct {
+	eventHandler service.EventHandler
+}
+
+// NewAnalyzer creates a new instance of event type Analyzer
+func NewAnalyzer(eventHandler service.EventHandler) *Analyzer {
+	return &Analyzer{
+		eventHandler: eventHandler,
+	}
+}
+
+// eventTypeIndex accumulates the distinct actors & repos of an event type
+type eventTypeIndex struct {
+	count  int
+	actors map[string]bool
+	repos  map[string]bool
+}
+
+// indexGroups creates map of groups (by key) of event types & map of the names of the groups from the events
+func indexGroups(eventHandler service.EventHandler, key KeyFunc) (map[string]map[string]*eventTypeIndex, map[string]string) {
+	groupMap := make(map[string]map[string]*eventTypeIndex)
+	nameMap := make(map[string]string)
+	for _, event := range eventHandler.Events {
+		k, name := key(event)
+		if k == "" {
+			continue
+		}
+		if groupMap[k] == nil {
+			groupMap[k] = map[string]*eventTypeIndex{}
+			nameMap[k] = name
+		}
+		index, ok := groupMap[k][event.Type]
+		if !ok {
+			index = &eventTypeIndex{
+				actors: map[string]bool{},
+				repos:  map[string]bool{},
+			}
+			groupMap[k][event.Type] = index
+		}
+		index.count++
+		if event.Actor != nil {
+			index.actors[event.Actor.ID] = true
+		}
+		if event.Repo != nil {
+			index.repos[event.Repo.ID] = true
+		}
+	}
+	return groupMap, nameMap
+}
+
+// GetEventTypes returns all the event types of the dataset sorted by provided sort function
+func (ea *Analyzer) GetEventTypes(fn func(ti, tj EventType) bool) []EventType {
+	groups := ea.GetGroups(byDataset, 0, 0, fn)
+	if len(groups) == 0 {
+		return []EventType{}
+	}
+	return groups[0].EventTypes
+}
+
+// GetGroups returns top groups by number of events based on provided limit & offset
+// event types in each group are sorted by provided sort function
+func (ea *Analyzer) GetGroups(key KeyFunc, limit, offset uint32, fn func(ti, tj EventType) bool) []Group {
+	groupMap, nameMap := indexGroups(ea.eventHandler, key)
+
+	all := make([]Group, 0, len(groupMap))
+	for k, eventTypeMap := range groupMap {
+		group := Group{Key: k, Name: nameMap[k]}
+		for _, index := range eventTypeMap {
+			group.Count += index.count
+		}
+		all = append(all, group)
+	}
+
+	indices := topk.Select(groupRanking(all), int(limit), int(offset))
+	groups := make([]Group, 0, len(indices))
+	for _, i := range indices {
+		group := all[i]
+		eventTypes := make([]EventType, 0, len(groupMap[group.Key]))
+		for eventType, index := range groupMap[group.Key] {
+			eventTypes = append(eventTypes, EventType{
+				Type:       eventType,
+				Count:      index.count,
+				Share:      float64(index.count) / float64(group.Count),
+				ActorCount: len(index.actors),
+				RepoCount:  len(index.repos),
+			})
+		}
+		for _, j := range topk.Select(eventTypeRanking{eventTypes: eventTypes, less: fn}, 0, 0) {
+			group.EventTypes = append(group.EventTypes, eventTypes[j])
+		}
+		groups = append(groups, group)
+	}
+
+	return groups
+}
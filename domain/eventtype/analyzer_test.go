@@ -0,0 +1,111 @@
+package eventtype
+
+import (
+	"testing"
+
+	"github.com/ameykpatil/github-data-analyzer/db/entities"
+	"github.com/ameykpatil/github-data-analyzer/service"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	actor1 = entities.Actor{
+		ID:       "111",
+		Username: "Actor1",
+	}
+	actor2 = entities.Actor{
+		ID:       "112",
+		Username: "Actor2",
+	}
+	repo1 = entities.Repo{
+		ID:   "441",
+		Name: "Owner1/Repo1",
+	}
+	repo2 = entities.Repo{
+		ID:   "442",
+		Name: "Owner1/Repo2",
+	}
+	repo3 = entities.Repo{
+		ID:   "443",
+		Name: "Owner2/Repo3",
+	}
+	events = map[string]*service.Event{
+		"331": {ID: "331", Type: "PushEvent", Actor: &actor1, Repo: &repo1},
+		"332": {ID: "332", Type: "PushEvent", Actor: &actor1, Repo: &repo2},
+		"333": {ID: "333", Type: "PushEvent", Actor: &actor2, Repo: &repo2},
+		"334": {ID: "334", Type: "WatchEvent", Actor: &actor2, Repo: &repo3},
+	}
+	byCount = func(ti, tj EventType) bool {
+		return ti.Count > tj.Count
+	}
+)
+
+func TestGetEventTypes(t *testing.T) {
+	analyzer := NewAnalyzer(service.EventHandler{DataStore: nil, Events: events})
+
+	got := analyzer.GetEventTypes(byCount)
+
+	assert.Equal(t, []EventType{
+		{Type: "PushEvent", Count: 3, Share: 0.75, ActorCount: 2, RepoCount: 2},
+		{Type: "WatchEvent", Count: 1, Share: 0.25, ActorCount: 1, RepoCount: 1},
+	}, got)
+}
+
+func TestGetGroups(t *testing.T) {
+	analyzer := NewAnalyzer(service.EventHandler{DataStore: nil, Events: events})
+
+	tests := []struct {
+		name string
+		key  KeyFunc
+		exp  []Group
+	}{
+		{
+			name: "by owner",
+			key:  ByOwner,
+			exp: []Group{
+				{Key: "Owner1", Name: "Owner1", Count: 3, EventTypes: []EventType{
+					{Type: "PushEvent", Count: 3, Share: 1, ActorCount: 2, RepoCount: 2},
+				}},
+				{Key: "Owner2", Name: "Owner2", Count: 1, EventTypes: []EventType{
+					{Type: "WatchEvent", Count: 1, Share: 1, ActorCount: 1, RepoCount: 1},
+				}},
+			},
+		},
+		{
+			name: "by user",
+			key:  ByUser,
+			exp: []Group{
+				{Key: "111", Name: "Actor1", Count: 2, EventTypes: []EventType{
+					{Type: "PushEvent", Count: 2, Share: 1, ActorCount: 1, RepoCount: 2},
+				}},
+				{Key: "112", Name: "Actor2", Count: 2, EventTypes: []EventType{
+					{Type: "PushEvent", Count: 1, Share: 0.5, ActorCount: 1, RepoCount: 1},
+					{Type: "WatchEvent", Count: 1, Share: 0.5, ActorCount: 1, RepoCount: 1},
+				}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.exp, analyzer.GetGroups(tt.key, 0, 0, byCount))
+		})
+	}
+}
+
+func TestGetGroupsByUserID(t *testing.T) {
+	renamed := entities.Actor{ID: actor1.ID, Username: "Renamed1"}
+	namesake := entities.Actor{ID: "113", Username: actor1.Username}
+	analyzer := NewAnalyzer(service.EventHandler{DataStore: nil, Events: map[string]*service.Event{
+		"331": {ID: "331", Type: "PushEvent", Actor: &actor1, Repo: &repo1},
+		"332": {ID: "332", Type: "PushEvent", Actor: &renamed, Repo: &repo1},
+		"333": {ID: "333", Type: "WatchEvent", Actor: &namesake, Repo: &repo2},
+	}})
+
+	// the renamed user is a single group & the user with the same username is another one
+	groups := analyzer.GetGroups(ByUser, 0, 0, byCount)
+	assert.Len(t, groups, 2)
+	assert.Equal(t, actor1.ID, groups[0].Key)
+	assert.Equal(t, 2, groups[0].Count)
+	assert.Equal(t, "113", groups[1].Key)
+	assert.Equal(t, actor1.Username, groups[1].Name)
+}
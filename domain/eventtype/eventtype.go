@@ -0,0 +1,59 @@
+package eventtype
+
+// EventType encapsulates the distribution properties of an event type
+// Share is the fraction of the events of the group which are of this type
+type EventType struct {
+	Type       string
+	Count      int
+	Share      float64
+	ActorCount int
+	RepoCount  int
+}
+
+// Group encapsulates the event type distribution of a group of events e.g. events on repos of an owner
+// Key identifies the group e.g. the id of the user & Name is the one to display e.g. the username
+type Group struct {
+	Key        string
+	Name       string
+	Count      int
+	EventTypes []EventType
+}
+
+// eventTypeRanking ranks the event types using the given sort function (topk Interface)
+type eventTypeRanking struct {
+	eventTypes []EventType
+	less       func(i, j EventType) bool
+}
+
+// Len is the number of event types to rank
+func (r eventTypeRanking) Len() int {
+	return len(r.eventTypes)
+}
+
+// Less reports whether the event type i ranks before the event type j
+func (r eventTypeRanking) Less(i, j int) bool {
+	return r.less(r.eventTypes[i], r.eventTypes[j])
+}
+
+// ID is used to break the ties between event types
+func (r eventTypeRanking) ID(i int) string {
+	return r.eventTypes[i].Type
+}
+
+// groupRanking ranks the groups by number of events (topk Interface)
+type groupRanking []Group
+
+// Len is the number of groups to rank
+func (r groupRanking) Len() int {
+	return len(r)
+}
+
+// Less reports whether the group i ranks before the group j
+func (r groupRanking) Less(i, j int) bool {
+	return r[i].Count > r[j].Count
+}
+
+// ID is used to break the ties between groups
+func (r groupRanking) ID(i int) string {
+	return r[i].Key
+}
@@ -0,0 +1,131 @@
+package graph
+
+import (
+	"sort"
+
+	"github.com/ameykpatil/github-data-analyzer/domain/topk"
+	"github.com/ameykpatil/github-data-analyzer/service"
+)
+
+// WeightFunc returns the weight of the edge e.g. number of commits
+type WeightFunc func(edge Edge) int
+
+// Analyzer encapsulates functionality of building the user-repo graph
+type Analyzer struct {
+	eventHandler service.EventHandler
+	nodeMap      map[string]*Node
+	edgeMap      map[[2]string]*Edge
+}
+
+// NewAnalyzer creates a new instance of graph Analyzer
+func NewAnalyzer(eventHandler service.EventHandler) *Analyzer {
+	nodeMap, edgeMap := indexGraph(eventHandler)
+	return &Analyzer{
+		eventHandler: eventHandler,
+		nodeMap:      nodeMap,
+		edgeMap:      edgeMap,
+	}
+}
+
+// UserNodeID returns the id of the node of the user, the ids are prefixed as user & repo ids can clash
+func UserNodeID(id string) string {
+	return UserNode + ":" + id
+}
+
+// RepoNodeID returns the id of the node of the repo
+func RepoNodeID(id string) string {
+	return RepoNode + ":" + id
+}
+
+// indexGraph creates maps of nodes & edges (by source & target) from the events
+func indexGraph(eventHandler service.EventHandler) (map[string]*Node, map[[2]string]*Edge) {
+	nodeMap := make(map[string]*Node)
+	edgeMap := make(map[[2]string]*Edge)
+	for _, event := range eventHandler.Events {
+		if event.Actor == nil || event.Repo == nil {
+			continue
+		}
+		source, target := UserNodeID(event.Actor.ID), RepoNodeID(event.Repo.ID)
+		if _, ok := nodeMap[source]; !ok {
+			nodeMap[source] = &Node{ID: source, Label: event.Actor.Username, Kind: UserNode}
+		}
+		if _, ok := nodeMap[target]; !ok {
+			nodeMap[target] = &Node{ID: target, Label: event.Repo.Name, Kind: RepoNode}
+		}
+		edge, ok := edgeMap[[2]string{source, target}]
+		if !ok {
+			edge = &Edge{
+				Source:         source,
+				Target:         target,
+				EventTypeCount: map[string]int{},
+			}
+			edgeMap[[2]string{source, target}] = edge
+		}
+		edge.CommitCount = edge.CommitCount + len(event.Commits)
+		edge.EventCount++
+		edge.EventTypeCount[event.Type] = edge.EventTypeCount[event.Type] + 1
+	}
+	return nodeMap, edgeMap
+}
+
+// GetGraph returns the graph with edges weighted by provided weight function
+// edges lighter than minWeight are pruned, then only topNodes nodes with highest weighted degree are kept (0 keeps all)
+// along with their edges & neighbours, nodes left without any edge are dropped
+func (ga *Analyzer) GetGraph(weight WeightFunc, minWeight int, topNodes uint32) *Graph {
+	edges := []Edge{}
+	degrees := map[string]int{}
+	for _, edge := range ga.edgeMap {
+		e := *edge
+		e.Weight = weight(e)
+		if e.Weight <= 0 || e.Weight < minWeight {
+			continue
+		}
+		edges = append(edges, e)
+		degrees[e.Source] += e.Weight
+		degrees[e.Target] += e.Weight
+	}
+
+	nodes := make([]Node, 0, len(degrees))
+	for id, degree := range degrees {
+		node := *ga.nodeMap[id]
+		node.Weight = degree
+		nodes = append(nodes, node)
+	}
+
+	// keep the top nodes with their edges & the nodes at the other end, the degrees are recomputed on the kept edges
+	if topNodes > 0 && int(topNodes) < len(nodes) {
+		top := map[string]bool{}
+		for _, i := range topk.Select(nodeRanking(nodes), int(topNodes), 0) {
+			top[nodes[i].ID] = true
+		}
+		keptEdges := []Edge{}
+		degrees = map[string]int{}
+		for _, edge := range edges {
+			if top[edge.Source] || top[edge.Target] {
+				keptEdges = append(keptEdges, edge)
+				degrees[edge.Source] += edge.Weight
+				degrees[edge.Target] += edge.Weight
+			}
+		}
+		keptNodes := []Node{}
+		for _, node := range nodes {
+			if degree, ok := degrees[node.ID]; ok {
+				node.Weight = degree
+				keptNodes = append(keptNodes, node)
+			}
+		}
+		edges, nodes = keptEdges, keptNodes
+	}
+
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].ID < nodes[j].ID
+	})
+	sort.Slice(edges, func(i, j int) bool {
+		if edges[i].Source == edges[j].Source {
+			return edges[i].Target < edges[j].Target
+		}
+		return edges[i].Source < edges[j].Source
+	})
+
+	return &Graph{Nodes: nodes, Edges: edges}
+}
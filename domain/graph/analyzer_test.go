@@ -0,0 +1,128 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/ameykpatil/github-data-analyzer/db/entities"
+	"github.com/ameykpatil/github-data-analyzer/service"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	actor1 = entities.Actor{
+		ID:       "111",
+		Username: "Actor1",
+	}
+	actor2 = entities.Actor{
+		ID:       "112",
+		Username: "Actor2",
+	}
+	repo1 = entities.Repo{
+		ID:   "441",
+		Name: "Owner1/Repo1",
+	}
+	repo2 = entities.Repo{
+		ID:   "442",
+		Name: "Owner2/Repo2",
+	}
+	commit1 = entities.Commit{
+		Sha:     "221",
+		Message: "Message 1",
+		EventID: "331",
+	}
+	commit2 = entities.Commit{
+		Sha:     "222",
+		Message: "Message 2",
+		EventID: "331",
+	}
+	events = map[string]*service.Event{
+		"331": {ID: "331", Type: "PushEvent", Actor: &actor1, Repo: &repo1, Commits: []entities.Commit{commit1, commit2}},
+		"332": {ID: "332", Type: "WatchEvent", Actor: &actor1, Repo: &repo1},
+		"333": {ID: "333", Type: "WatchEvent", Actor: &actor2, Repo: &repo1},
+		"334": {ID: "334", Type: "ForkEvent", Actor: &actor2, Repo: &repo2},
+	}
+	byEvents = func(edge Edge) int {
+		return edge.EventCount
+	}
+)
+
+func TestGetGraph(t *testing.T) {
+	analyzer := NewAnalyzer(service.EventHandler{DataStore: nil, Events: events})
+
+	tests := []struct {
+		name      string
+		weight    WeightFunc
+		minWeight int
+		topNodes  uint32
+		exp       *Graph
+	}{
+		{
+			name:   "all nodes & edges",
+			weight: byEvents,
+			exp: &Graph{
+				Nodes: []Node{
+					{ID: "repo:441", Label: "Owner1/Repo1", Kind: RepoNode, Weight: 3},
+					{ID: "repo:442", Label: "Owner2/Repo2", Kind: RepoNode, Weight: 1},
+					{ID: "user:111", Label: "Actor1", Kind: UserNode, Weight: 2},
+					{ID: "user:112", Label: "Actor2", Kind: UserNode, Weight: 2},
+				},
+				Edges: []Edge{
+					{Source: "user:111", Target: "repo:441", Weight: 2, CommitCount: 2, EventCount: 2, EventTypeCount: map[string]int{"PushEvent": 1, "WatchEvent": 1}},
+					{Source: "user:112", Target: "repo:441", Weight: 1, EventCount: 1, EventTypeCount: map[string]int{"WatchEvent": 1}},
+					{Source: "user:112", Target: "repo:442", Weight: 1, EventCount: 1, EventTypeCount: map[string]int{"ForkEvent": 1}},
+				},
+			},
+		},
+		{
+			name:      "pruned by minimum weight",
+			weight:    byEvents,
+			minWeight: 2,
+			exp: &Graph{
+				Nodes: []Node{
+					{ID: "repo:441", Label: "Owner1/Repo1", Kind: RepoNode, Weight: 2},
+					{ID: "user:111", Label: "Actor1", Kind: UserNode, Weight: 2},
+				},
+				Edges: []Edge{
+					{Source: "user:111", Target: "repo:441", Weight: 2, CommitCount: 2, EventCount: 2, EventTypeCount: map[string]int{"PushEvent": 1, "WatchEvent": 1}},
+				},
+			},
+		},
+		{
+			// the top node keeps its edges even if its neighbours are not top nodes
+			name:     "top node & its neighbours",
+			weight:   byEvents,
+			topNodes: 1,
+			exp: &Graph{
+				Nodes: []Node{
+					{ID: "repo:441", Label: "Owner1/Repo1", Kind: RepoNode, Weight: 3},
+					{ID: "user:111", Label: "Actor1", Kind: UserNode, Weight: 2},
+					{ID: "user:112", Label: "Actor2", Kind: UserNode, Weight: 1},
+				},
+				Edges: []Edge{
+					{Source: "user:111", Target: "repo:441", Weight: 2, CommitCount: 2, EventCount: 2, EventTypeCount: map[string]int{"PushEvent": 1, "WatchEvent": 1}},
+					{Source: "user:112", Target: "repo:441", Weight: 1, EventCount: 1, EventTypeCount: map[string]int{"WatchEvent": 1}},
+				},
+			},
+		},
+		{
+			name: "weighted by commits",
+			weight: func(edge Edge) int {
+				return edge.CommitCount
+			},
+			exp: &Graph{
+				Nodes: []Node{
+					{ID: "repo:441", Label: "Owner1/Repo1", Kind: RepoNode, Weight: 2},
+					{ID: "user:111", Label: "Actor1", Kind: UserNode, Weight: 2},
+				},
+				Edges: []Edge{
+					{Source: "user:111", Target: "repo:441", Weight: 2, CommitCount: 2, EventCount: 2, EventTypeCount: map[string]int{"PushEvent": 1, "WatchEvent": 1}},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.exp, analyzer.GetGraph(tt.weight, tt.minWeight, tt.topNodes))
+		})
+	}
+}
@@ -0,0 +1,324 @@
+package graph
+
+import (
+	"sort"
+
+	"github.com/ameykpatil/github-data-analyzer/domain/topk"
+)
+
+// maximum number of levels of aggregation & of passes over the nodes in a level of the Louvain method
+const (
+	maxLevels = 20
+	maxPasses = 100
+)
+
+// CommunityOptions configure the projection of the user-repo graph & the community detection
+// MinWeight is the minimum weight of the edge of a user to a repo for the user to be a contributor of the repo,
+// the repos with more than MaxRepoUsers contributors are not projected (0 projects all) as they link everyone
+// & the communities with less than MinSize members are dropped
+type CommunityOptions struct {
+	MinWeight    int
+	MaxRepoUsers int
+	MinSize      int
+}
+
+// Community is a group of users who work together
+// Members are ranked by the number of their co-contributors & SharedRepos are the repos with at least 2 members
+type Community struct {
+	ID          int
+	Members     []Node
+	SharedRepos []SharedRepo
+}
+
+// SharedRepo is a repo & the number of members of a community who contributed to it
+type SharedRepo struct {
+	Node
+	Members int
+}
+
+// Communities are the communities of the user-user co-contribution graph ordered by size
+// Modularity measures how much denser the links inside the communities are than expected at random (-0.5 to 1)
+type Communities struct {
+	Graph       *Graph
+	Communities []Community
+	Modularity  float64
+}
+
+// GetCommunities projects the user-repo graph onto the user-user co-contribution graph, where the weight of the edge
+// between 2 users is the number of repos both contributed to, & detects the communities with the Louvain method
+func (ga *Analyzer) GetCommunities(weight WeightFunc, options CommunityOptions) Communities {
+	// contributors of each repo, ordered by id
+	repoUsers := map[string][]string{}
+	for _, edge := range ga.edgeMap {
+		if w := weight(*edge); w > 0 && w >= options.MinWeight {
+			repoUsers[edge.Target] = append(repoUsers[edge.Target], edge.Source)
+		}
+	}
+	pairs := map[[2]string]int{}
+	userRepos := map[string][]string{}
+	for repoID, users := range repoUsers {
+		if len(users) < 2 || (options.MaxRepoUsers > 0 && len(users) > options.MaxRepoUsers) {
+			continue
+		}
+		sort.Strings(users)
+		for i, source := range users {
+			userRepos[source] = append(userRepos[source], repoID)
+			for _, target := range users[i+1:] {
+				pairs[[2]string{source, target}]++
+			}
+		}
+	}
+
+	g := projection(ga.nodeMap, pairs)
+	labels := detectCommunities(g)
+	result := Communities{Graph: g, Modularity: modularity(g, labels)}
+
+	// group the members by label, the nodes are ordered by id
+	groups := map[int][]Node{}
+	for i, node := range g.Nodes {
+		groups[labels[i]] = append(groups[labels[i]], node)
+	}
+	for _, members := range groups {
+		if len(members) < options.MinSize {
+			continue
+		}
+		ranked := make([]Node, 0, len(members))
+		for _, i := range topk.Select(nodeRanking(members), 0, 0) {
+			ranked = append(ranked, members[i])
+		}
+		result.Communities = append(result.Communities, Community{
+			Members:     ranked,
+			SharedRepos: ga.sharedRepos(members, userRepos),
+		})
+	}
+
+	sort.Slice(result.Communities, func(i, j int) bool {
+		ci, cj := result.Communities[i], result.Communities[j]
+		if len(ci.Members) != len(cj.Members) {
+			return len(ci.Members) > len(cj.Members)
+		}
+		return ci.Members[0].ID < cj.Members[0].ID
+	})
+	for i := range result.Communities {
+		result.Communities[i].ID = i + 1
+	}
+	return result
+}
+
+// projection creates the user-user graph from the number of shared repos of the pairs of users
+func projection(nodeMap map[string]*Node, pairs map[[2]string]int) *Graph {
+	degrees := map[string]int{}
+	edges := make([]Edge, 0, len(pairs))
+	for pair, shared := range pairs {
+		edges = append(edges, Edge{Source: pair[0], Target: pair[1], Weight: shared})
+		degrees[pair[0]] += shared
+		degrees[pair[1]] += shared
+	}
+	nodes := make([]Node, 0, len(degrees))
+	for id, degree := range degrees {
+		node := *nodeMap[id]
+		node.Weight = degree
+		nodes = append(nodes, node)
+	}
+
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].ID < nodes[j].ID
+	})
+	sort.Slice(edges, func(i, j int) bool {
+		if edges[i].Source == edges[j].Source {
+			return edges[i].Target < edges[j].Target
+		}
+		return edges[i].Source < edges[j].Source
+	})
+	return &Graph{Nodes: nodes, Edges: edges}
+}
+
+// link is a weighted edge of the graph between the nodes by index
+type link struct {
+	from   int
+	to     int
+	weight float64
+}
+
+// detectCommunities returns the community label of each node with the Louvain method, i.e. the nodes are moved to
+// the community of a neighbour with the highest modularity gain until none improves it & then the communities are
+// merged into single nodes, as long as any node moves
+// the nodes are visited in the order of id & ties keep the current community so the result is deterministic
+func detectCommunities(g *Graph) []int {
+	index := make(map[string]int, len(g.Nodes))
+	for i, node := range g.Nodes {
+		index[node.ID] = i
+	}
+	links := make([]link, 0, len(g.Edges))
+	for _, edge := range g.Edges {
+		links = append(links, link{from: index[edge.Source], to: index[edge.Target], weight: float64(edge.Weight)})
+	}
+
+	labels := make([]int, len(g.Nodes))
+	for i := range labels {
+		labels[i] = i
+	}
+	n := len(g.Nodes)
+	for level := 0; level < maxLevels; level++ {
+		communities, count, moved := moveNodes(n, links)
+		if !moved {
+			break
+		}
+		for i := range labels {
+			labels[i] = communities[labels[i]]
+		}
+		n, links = count, aggregate(communities, links)
+	}
+	return labels
+}
+
+// moveNodes moves the nodes between the communities while the modularity improves
+// it returns the community of each node numbered in the order of their first node, the number of communities
+// & whether any node moved
+func moveNodes(n int, links []link) ([]int, int, bool) {
+	neighbours := make([][]link, n)
+	degrees := make([]float64, n)
+	total := 0.0
+	for _, l := range links {
+		total += l.weight
+		degrees[l.from] += l.weight
+		degrees[l.to] += l.weight
+		if l.from != l.to {
+			neighbours[l.from] = append(neighbours[l.from], l)
+			neighbours[l.to] = append(neighbours[l.to], link{from: l.to, to: l.from, weight: l.weight})
+		}
+	}
+
+	communities := make([]int, n)
+	totals := make([]float64, n)
+	for i := range communities {
+		communities[i] = i
+		totals[i] = degrees[i]
+	}
+	if total == 0 {
+		return communities, n, false
+	}
+
+	moved := false
+	for pass := 0; pass < maxPasses; pass++ {
+		improved := false
+		for i := 0; i < n; i++ {
+			current := communities[i]
+			weights := map[int]float64{}
+			for _, l := range neighbours[i] {
+				weights[communities[l.to]] += l.weight
+			}
+
+			// the gain of adding the node to a community, without the node
+			totals[current] -= degrees[i]
+			best, bestGain := current, weights[current]-totals[current]*degrees[i]/(2*total)
+			for _, l := range neighbours[i] {
+				candidate := communities[l.to]
+				if gain := weights[candidate] - totals[candidate]*degrees[i]/(2*total); gain > bestGain+1e-12 {
+					best, bestGain = candidate, gain
+				}
+			}
+			totals[best] += degrees[i]
+			if best != current {
+				communities[i], improved, moved = best, true, true
+			}
+		}
+		if !improved {
+			break
+		}
+	}
+
+	// number the communities in the order of their first node
+	numbers := map[int]int{}
+	for i, c := range communities {
+		if _, ok := numbers[c]; !ok {
+			numbers[c] = len(numbers)
+		}
+		communities[i] = numbers[c]
+	}
+	return communities, len(numbers), moved
+}
+
+// aggregate returns the links between the communities, the links inside a community become a self link
+func aggregate(communities []int, links []link) []link {
+	weights := map[[2]int]float64{}
+	for _, l := range links {
+		from, to := communities[l.from], communities[l.to]
+		if from > to {
+			from, to = to, from
+		}
+		weights[[2]int{from, to}] += l.weight
+	}
+	aggregated := make([]link, 0, len(weights))
+	for pair, weight := range weights {
+		aggregated = append(aggregated, link{from: pair[0], to: pair[1], weight: weight})
+	}
+	sort.Slice(aggregated, func(i, j int) bool {
+		if aggregated[i].from == aggregated[j].from {
+			return aggregated[i].to < aggregated[j].to
+		}
+		return aggregated[i].from < aggregated[j].from
+	})
+	return aggregated
+}
+
+// modularity returns the weighted modularity of the labelling of the nodes of the graph
+func modularity(g *Graph, labels []int) float64 {
+	total := 0.0
+	for _, edge := range g.Edges {
+		total += float64(edge.Weight)
+	}
+	if total == 0 {
+		return 0
+	}
+
+	label := make(map[string]int, len(g.Nodes))
+	degrees := map[int]float64{}
+	for i, node := range g.Nodes {
+		label[node.ID] = labels[i]
+		degrees[labels[i]] += float64(node.Weight)
+	}
+	inside := map[int]float64{}
+	for _, edge := range g.Edges {
+		if label[edge.Source] == label[edge.Target] {
+			inside[label[edge.Source]] += float64(edge.Weight)
+		}
+	}
+
+	// the labels are summed in order so that the result is same on every run
+	order := make([]int, 0, len(degrees))
+	for l := range degrees {
+		order = append(order, l)
+	}
+	sort.Ints(order)
+	q := 0.0
+	for _, l := range order {
+		q += inside[l]/total - (degrees[l]/(2*total))*(degrees[l]/(2*total))
+	}
+	return q
+}
+
+// sharedRepos returns the repos at least 2 of the members contributed to, ranked by the number of members
+func (ga *Analyzer) sharedRepos(members []Node, userRepos map[string][]string) []SharedRepo {
+	counts := map[string]int{}
+	for _, member := range members {
+		for _, repoID := range userRepos[member.ID] {
+			counts[repoID]++
+		}
+	}
+	// the weight of the repos is the number of members, used to rank them
+	repos := []Node{}
+	for repoID, count := range counts {
+		if count >= 2 {
+			node := *ga.nodeMap[repoID]
+			node.Weight = count
+			repos = append(repos, node)
+		}
+	}
+
+	shared := make([]SharedRepo, 0, len(repos))
+	for _, i := range topk.Select(nodeRanking(repos), 0, 0) {
+		shared = append(shared, SharedRepo{Node: *ga.nodeMap[repos[i].ID], Members: repos[i].Weight})
+	}
+	return shared
+}
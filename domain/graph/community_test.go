@@ -0,0 +1,90 @@
+package graph
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strconv"
+	"testing"
+
+	"github.com/ameykpatil/github-data-analyzer/db/entities"
+	"github.com/ameykpatil/github-data-analyzer/service"
+	"github.com/stretchr/testify/assert"
+)
+
+// communityEvents creates push events of the users (by id) on the repos (by id)
+func communityEvents(contributions map[string][]string) map[string]*service.Event {
+	all := map[string]*service.Event{}
+	for repoID, userIDs := range contributions {
+		r := &entities.Repo{ID: repoID, Name: "owner/" + repoID}
+		for _, userID := range userIDs {
+			id := strconv.Itoa(len(all))
+			all[id] = &service.Event{ID: id, Type: "PushEvent", Actor: &entities.Actor{ID: userID, Username: "user" + userID}, Repo: r}
+		}
+	}
+	return all
+}
+
+func TestGetCommunities(t *testing.T) {
+	// users 1, 2 & 3 work together on repos a & b, users 4 & 5 on repo c, user 3 & 4 meet on repo d once
+	// repo e has everyone & is not projected
+	analyzer := NewAnalyzer(service.EventHandler{DataStore: nil, Events: communityEvents(map[string][]string{
+		"a": {"1", "2", "3"},
+		"b": {"1", "2", "3"},
+		"c": {"4", "5"},
+		"d": {"3", "4"},
+		"e": {"1", "2", "3", "4", "5", "6"},
+		"f": {"7"},
+	})})
+
+	got := analyzer.GetCommunities(byEvents, CommunityOptions{MinWeight: 1, MaxRepoUsers: 5, MinSize: 2})
+	assert.Len(t, got.Graph.Nodes, 5)
+	assert.Len(t, got.Graph.Edges, 5)
+	assert.Equal(t, Edge{Source: "user:1", Target: "user:2", Weight: 2}, got.Graph.Edges[0])
+	assert.Greater(t, got.Modularity, 0.0)
+
+	assert.Len(t, got.Communities, 2)
+	assert.Equal(t, 1, got.Communities[0].ID)
+	assert.Equal(t, []string{"user:3", "user:1", "user:2"}, nodeIDs(got.Communities[0].Members))
+	assert.Equal(t, []SharedRepo{
+		{Node: Node{ID: "repo:a", Label: "owner/a", Kind: RepoNode}, Members: 3},
+		{Node: Node{ID: "repo:b", Label: "owner/b", Kind: RepoNode}, Members: 3},
+	}, got.Communities[0].SharedRepos)
+	assert.Equal(t, []string{"user:4", "user:5"}, nodeIDs(got.Communities[1].Members))
+
+	// the communities smaller than min size are dropped
+	got = analyzer.GetCommunities(byEvents, CommunityOptions{MinWeight: 1, MaxRepoUsers: 5, MinSize: 3})
+	assert.Len(t, got.Communities, 1)
+
+	// with the big repo user 6 is linked to everyone & joins the smaller group
+	got = analyzer.GetCommunities(byEvents, CommunityOptions{MinWeight: 1, MinSize: 2})
+	assert.Len(t, got.Graph.Nodes, 6)
+	assert.Len(t, got.Communities, 2)
+	assert.Equal(t, []string{"user:4", "user:5", "user:6"}, nodeIDs(got.Communities[1].Members))
+}
+
+func TestWriteCommunitiesGraphML(t *testing.T) {
+	analyzer := NewAnalyzer(service.EventHandler{DataStore: nil, Events: communityEvents(map[string][]string{
+		"a": {"1", "2"},
+		"b": {"3", "4"},
+	})})
+	c := analyzer.GetCommunities(byEvents, CommunityOptions{MinWeight: 1, MinSize: 2})
+
+	var buf bytes.Buffer
+	assert.Nil(t, WriteCommunitiesGraphML(&buf, c))
+
+	var doc graphML
+	assert.Nil(t, xml.Unmarshal(buf.Bytes(), &doc))
+	assert.Len(t, doc.Keys, 4)
+	assert.Len(t, doc.Graph.Nodes, 4)
+	assert.Equal(t, []graphMLData{{Key: "label", Value: "user1"}, {Key: "degree", Value: "1"}, {Key: "community", Value: "1"}}, doc.Graph.Nodes[0].Data)
+	assert.Equal(t, "2", doc.Graph.Nodes[2].Data[2].Value)
+	assert.Equal(t, []graphMLData{{Key: "weight", Value: "1"}}, doc.Graph.Edges[0].Data)
+}
+
+func nodeIDs(nodes []Node) []string {
+	ids := []string{}
+	for _, node := range nodes {
+		ids = append(ids, node.ID)
+	}
+	return ids
+}
@@ -0,0 +1,281 @@
+package graph
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// graphML is the xml document of GraphML format
+type graphML struct {
+	XMLName xml.Name     `xml:"graphml"`
+	Xmlns   string       `xml:"xmlns,attr"`
+	Keys    []graphMLKey `xml:"key"`
+	Graph   graphMLGraph `xml:"graph"`
+}
+
+type graphMLKey struct {
+	ID   string `xml:"id,attr"`
+	For  string `xml:"for,attr"`
+	Name string `xml:"attr.name,attr"`
+	Type string `xml:"attr.type,attr"`
+}
+
+type graphMLGraph struct {
+	ID          string        `xml:"id,attr"`
+	EdgeDefault string        `xml:"edgedefault,attr"`
+	Nodes       []graphMLNode `xml:"node"`
+	Edges       []graphMLEdge `xml:"edge"`
+}
+
+type graphMLNode struct {
+	ID   string        `xml:"id,attr"`
+	Data []graphMLData `xml:"data"`
+}
+
+type graphMLEdge struct {
+	Source string        `xml:"source,attr"`
+	Target string        `xml:"target,attr"`
+	Data   []graphMLData `xml:"data"`
+}
+
+type graphMLData struct {
+	Key   string `xml:"key,attr"`
+	Value string `xml:",chardata"`
+}
+
+// WriteGraphML writes the graph in GraphML format
+func WriteGraphML(w io.Writer, g *Graph) error {
+	eventTypes := g.EventTypes()
+	doc := graphML{
+		Xmlns: "http://graphml.graphdrawing.org/xmlns",
+		Keys: []graphMLKey{
+			{ID: "label", For: "node", Name: "label", Type: "string"},
+			{ID: "kind", For: "node", Name: "kind", Type: "string"},
+			{ID: "degree", For: "node", Name: "degree", Type: "int"},
+			{ID: "weight", For: "edge", Name: "weight", Type: "int"},
+			{ID: "commits", For: "edge", Name: "commits", Type: "int"},
+			{ID: "events", For: "edge", Name: "events", Type: "int"},
+		},
+		Graph: graphMLGraph{ID: "G", EdgeDefault: "undirected"},
+	}
+	for _, eventType := range eventTypes {
+		doc.Keys = append(doc.Keys, graphMLKey{ID: eventType, For: "edge", Name: eventType, Type: "int"})
+	}
+	for _, node := range g.Nodes {
+		doc.Graph.Nodes = append(doc.Graph.Nodes, graphMLNode{
+			ID: node.ID,
+			Data: []graphMLData{
+				{Key: "label", Value: node.Label},
+				{Key: "kind", Value: node.Kind},
+				{Key: "degree", Value: strconv.Itoa(node.Weight)},
+			},
+		})
+	}
+	for _, edge := range g.Edges {
+		e := graphMLEdge{
+			Source: edge.Source,
+			Target: edge.Target,
+			Data: []graphMLData{
+				{Key: "weight", Value: strconv.Itoa(edge.Weight)},
+				{Key: "commits", Value: strconv.Itoa(edge.CommitCount)},
+				{Key: "events", Value: strconv.Itoa(edge.EventCount)},
+			},
+		}
+		for _, eventType := range eventTypes {
+			if count, ok := edge.EventTypeCount[eventType]; ok {
+				e.Data = append(e.Data, graphMLData{Key: eventType, Value: strconv.Itoa(count)})
+			}
+		}
+		doc.Graph.Edges = append(doc.Graph.Edges, e)
+	}
+	return writeXML(w, doc)
+}
+
+// WriteCommunitiesGraphML writes the co-contribution graph in GraphML format, with the community of each user
+// the users not in any of the communities have community 0
+func WriteCommunitiesGraphML(w io.Writer, c Communities) error {
+	community := map[string]int{}
+	for _, com := range c.Communities {
+		for _, member := range com.Members {
+			community[member.ID] = com.ID
+		}
+	}
+	doc := graphML{
+		Xmlns: "http://graphml.graphdrawing.org/xmlns",
+		Keys: []graphMLKey{
+			{ID: "label", For: "node", Name: "label", Type: "string"},
+			{ID: "degree", For: "node", Name: "degree", Type: "int"},
+			{ID: "community", For: "node", Name: "community", Type: "int"},
+			{ID: "weight", For: "edge", Name: "weight", Type: "int"},
+		},
+		Graph: graphMLGraph{ID: "G", EdgeDefault: "undirected"},
+	}
+	for _, node := range c.Graph.Nodes {
+		doc.Graph.Nodes = append(doc.Graph.Nodes, graphMLNode{
+			ID: node.ID,
+			Data: []graphMLData{
+				{Key: "label", Value: node.Label},
+				{Key: "degree", Value: strconv.Itoa(node.Weight)},
+				{Key: "community", Value: strconv.Itoa(community[node.ID])},
+			},
+		})
+	}
+	for _, edge := range c.Graph.Edges {
+		doc.Graph.Edges = append(doc.Graph.Edges, graphMLEdge{
+			Source: edge.Source,
+			Target: edge.Target,
+			Data:   []graphMLData{{Key: "weight", Value: strconv.Itoa(edge.Weight)}},
+		})
+	}
+	return writeXML(w, doc)
+}
+
+// gexf is the xml document of GEXF format
+type gexf struct {
+	XMLName xml.Name  `xml:"gexf"`
+	Xmlns   string    `xml:"xmlns,attr"`
+	Version string    `xml:"version,attr"`
+	Graph   gexfGraph `xml:"graph"`
+}
+
+type gexfGraph struct {
+	DefaultEdgeType string           `xml:"defaultedgetype,attr"`
+	Mode            string           `xml:"mode,attr"`
+	Attributes      []gexfAttributes `xml:"attributes"`
+	Nodes           []gexfNode       `xml:"nodes>node"`
+	Edges           []gexfEdge       `xml:"edges>edge"`
+}
+
+type gexfAttributes struct {
+	Class      string          `xml:"class,attr"`
+	Attributes []gexfAttribute `xml:"attribute"`
+}
+
+type gexfAttribute struct {
+	ID    string `xml:"id,attr"`
+	Title string `xml:"title,attr"`
+	Type  string `xml:"type,attr"`
+}
+
+type gexfNode struct {
+	ID        string         `xml:"id,attr"`
+	Label     string         `xml:"label,attr"`
+	AttValues []gexfAttValue `xml:"attvalues>attvalue"`
+}
+
+type gexfEdge struct {
+	ID        string         `xml:"id,attr"`
+	Source    string         `xml:"source,attr"`
+	Target    string         `xml:"target,attr"`
+	Weight    int            `xml:"weight,attr"`
+	AttValues []gexfAttValue `xml:"attvalues>attvalue"`
+}
+
+type gexfAttValue struct {
+	For   string `xml:"for,attr"`
+	Value string `xml:"value,attr"`
+}
+
+// WriteGEXF writes the graph in GEXF format
+func WriteGEXF(w io.Writer, g *Graph) error {
+	eventTypes := g.EventTypes()
+	edgeAttributes := gexfAttributes{
+		Class: "edge",
+		Attributes: []gexfAttribute{
+			{ID: "commits", Title: "commits", Type: "integer"},
+			{ID: "events", Title: "events", Type: "integer"},
+		},
+	}
+	for _, eventType := range eventTypes {
+		edgeAttributes.Attributes = append(edgeAttributes.Attributes, gexfAttribute{ID: eventType, Title: eventType, Type: "integer"})
+	}
+	doc := gexf{
+		Xmlns:   "http://gexf.net/1.3",
+		Version: "1.3",
+		Graph: gexfGraph{
+			DefaultEdgeType: "undirected",
+			Mode:            "static",
+			Attributes: []gexfAttributes{
+				{
+					Class: "node",
+					Attributes: []gexfAttribute{
+						{ID: "kind", Title: "kind", Type: "string"},
+						{ID: "degree", Title: "degree", Type: "integer"},
+					},
+				},
+				edgeAttributes,
+			},
+		},
+	}
+	for _, node := range g.Nodes {
+		doc.Graph.Nodes = append(doc.Graph.Nodes, gexfNode{
+			ID:    node.ID,
+			Label: node.Label,
+			AttValues: []gexfAttValue{
+				{For: "kind", Value: node.Kind},
+				{For: "degree", Value: strconv.Itoa(node.Weight)},
+			},
+		})
+	}
+	for i, edge := range g.Edges {
+		e := gexfEdge{
+			ID:     strconv.Itoa(i),
+			Source: edge.Source,
+			Target: edge.Target,
+			Weight: edge.Weight,
+			AttValues: []gexfAttValue{
+				{For: "commits", Value: strconv.Itoa(edge.CommitCount)},
+				{For: "events", Value: strconv.Itoa(edge.EventCount)},
+			},
+		}
+		for _, eventType := range eventTypes {
+			if count, ok := edge.EventTypeCount[eventType]; ok {
+				e.AttValues = append(e.AttValues, gexfAttValue{For: eventType, Value: strconv.Itoa(count)})
+			}
+		}
+		doc.Graph.Edges = append(doc.Graph.Edges, e)
+	}
+	return writeXML(w, doc)
+}
+
+// writeXML writes the indented xml document with the header
+func writeXML(w io.Writer, doc interface{}) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(doc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// WriteDOT writes the graph in Graphviz DOT format
+func WriteDOT(w io.Writer, g *Graph) error {
+	var str strings.Builder
+	str.WriteString("graph G {\n")
+	for _, node := range g.Nodes {
+		shape := "ellipse"
+		if node.Kind == RepoNode {
+			shape = "box"
+		}
+		fmt.Fprintf(&str, "  %s [label=%s, shape=%s, degree=%d];\n", quoteDOT(node.ID), quoteDOT(node.Label), shape, node.Weight)
+	}
+	for _, edge := range g.Edges {
+		fmt.Fprintf(&str, "  %s -- %s [weight=%d, commits=%d, events=%d];\n",
+			quoteDOT(edge.Source), quoteDOT(edge.Target), edge.Weight, edge.CommitCount, edge.EventCount)
+	}
+	str.WriteString("}\n")
+	_, err := io.WriteString(w, str.String())
+	return err
+}
+
+// quoteDOT quotes the id for DOT format
+func quoteDOT(id string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(id) + `"`
+}
@@ -0,0 +1,55 @@
+package graph
+
+import (
+	"bytes"
+	"encoding/xml"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var g = &Graph{
+	Nodes: []Node{
+		{ID: "repo:441", Label: "Owner1/Repo<1>", Kind: RepoNode, Weight: 2},
+		{ID: "user:111", Label: "Actor\"1\"", Kind: UserNode, Weight: 2},
+	},
+	Edges: []Edge{
+		{Source: "user:111", Target: "repo:441", Weight: 2, CommitCount: 3, EventCount: 2, EventTypeCount: map[string]int{"PushEvent": 2}},
+	},
+}
+
+func TestWriteGraphML(t *testing.T) {
+	var buf bytes.Buffer
+	assert.Nil(t, WriteGraphML(&buf, g))
+
+	var doc graphML
+	assert.Nil(t, xml.Unmarshal(buf.Bytes(), &doc))
+	assert.Len(t, doc.Keys, 7)
+	assert.Equal(t, "Owner1/Repo<1>", doc.Graph.Nodes[0].Data[0].Value)
+	assert.Equal(t, []graphMLData{{Key: "weight", Value: "2"}, {Key: "commits", Value: "3"}, {Key: "events", Value: "2"}, {Key: "PushEvent", Value: "2"}}, doc.Graph.Edges[0].Data)
+}
+
+func TestWriteGEXF(t *testing.T) {
+	var buf bytes.Buffer
+	assert.Nil(t, WriteGEXF(&buf, g))
+
+	var doc gexf
+	assert.Nil(t, xml.Unmarshal(buf.Bytes(), &doc))
+	assert.Len(t, doc.Graph.Nodes, 2)
+	assert.Equal(t, "Actor\"1\"", doc.Graph.Nodes[1].Label)
+	assert.Equal(t, 2, doc.Graph.Edges[0].Weight)
+	assert.Equal(t, "user:111", doc.Graph.Edges[0].Source)
+}
+
+func TestWriteDOT(t *testing.T) {
+	var buf bytes.Buffer
+	assert.Nil(t, WriteDOT(&buf, g))
+
+	exp := `graph G {
+  "repo:441" [label="Owner1/Repo<1>", shape=box, degree=2];
+  "user:111" [label="Actor\"1\"", shape=ellipse, degree=2];
+  "user:111" -- "repo:441" [weight=2, commits=3, events=2];
+}
+`
+	assert.Equal(t, exp, buf.String())
+}
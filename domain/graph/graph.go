@@ -0,0 +1,69 @@
+package graph
+
+import "sort"
+
+// node kinds of the bipartite graph
+const (
+	UserNode = "user"
+	RepoNode = "repo"
+)
+
+// Node is a user or a repo in the graph
+// Weight is the weighted degree i.e. sum of the weights of its edges
+type Node struct {
+	ID     string
+	Label  string
+	Kind   string
+	Weight int
+}
+
+// Edge connects a user to a repo the user was active on
+type Edge struct {
+	Source         string
+	Target         string
+	Weight         int
+	CommitCount    int
+	EventCount     int
+	EventTypeCount map[string]int
+}
+
+// Graph is the bipartite user-repo collaboration graph
+// nodes are sorted by id & edges by source & target, so that the exports are same on every run
+type Graph struct {
+	Nodes []Node
+	Edges []Edge
+}
+
+// EventTypes returns the sorted event types present on the edges of the graph
+func (g *Graph) EventTypes() []string {
+	seen := map[string]bool{}
+	eventTypes := []string{}
+	for _, edge := range g.Edges {
+		for eventType := range edge.EventTypeCount {
+			if !seen[eventType] {
+				seen[eventType] = true
+				eventTypes = append(eventTypes, eventType)
+			}
+		}
+	}
+	sort.Strings(eventTypes)
+	return eventTypes
+}
+
+// nodeRanking ranks the nodes by weighted degree (topk Interface)
+type nodeRanking []Node
+
+// Len is the number of nodes to rank
+func (r nodeRanking) Len() int {
+	return len(r)
+}
+
+// Less reports whether the node i ranks before the node j
+func (r nodeRanking) Less(i, j int) bool {
+	return r[i].Weight > r[j].Weight
+}
+
+// ID is used to break the ties between nodes
+func (r nodeRanking) ID(i int) string {
+	return r[i].ID
+}
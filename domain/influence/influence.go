@@ -0,0 +1,243 @@
+// Package influence scores the users & repos with PageRank over the users who are active on the same repos
+package influence
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ameykpatil/github-data-analyzer/service"
+)
+
+// defaults of the options
+const (
+	DefaultDamping = 0.85
+	DefaultWeight  = 1.0
+	maxIterations  = 100
+	tolerance      = 1e-10
+)
+
+// DefaultWeights are the edge weights of the event types which differ from DefaultWeight
+// watching & forking a repo are weaker signals than contributing to it
+var DefaultWeights = map[string]float64{
+	"WatchEvent": 0.25,
+	"ForkEvent":  0.25,
+}
+
+// endorsingTypes are the event types which endorse a repo without contributing to it, so they gain no influence from it
+var endorsingTypes = map[string]bool{
+	"WatchEvent": true,
+	"ForkEvent":  true,
+}
+
+// Options configure the influence scores
+// Damping is the probability of following an edge instead of jumping to a random user, 0 means DefaultDamping
+// Weights are the edge weights of the event types overriding DefaultWeights, 0 ignores the events of the type
+type Options struct {
+	Damping float64
+	Weights map[string]float64
+}
+
+// Scores are the influence scores of the users & repos by id, the mean score of the users is 1 & so is the one of the repos
+type Scores struct {
+	Users map[string]float64
+	Repos map[string]float64
+}
+
+// weight returns the edge weight of the event type
+func (o Options) weight(eventType string) float64 {
+	if weight, ok := o.Weights[eventType]; ok {
+		return weight
+	}
+	if weight, ok := DefaultWeights[eventType]; ok {
+		return weight
+	}
+	return DefaultWeight
+}
+
+// ParseWeights parses the edge weights of the event types e.g. WatchEvent=0.5
+func ParseWeights(specs map[string]string) (map[string]float64, error) {
+	weights := make(map[string]float64, len(specs))
+	for eventType, spec := range specs {
+		if !service.IsEventType(eventType) {
+			return nil, errors.New("invalid event type " + eventType)
+		}
+		weight, err := strconv.ParseFloat(strings.TrimSpace(spec), 64)
+		if err != nil || weight < 0 || math.IsInf(weight, 0) {
+			return nil, errors.New("invalid weight " + spec + " of " + eventType)
+		}
+		weights[eventType] = weight
+	}
+	return weights, nil
+}
+
+// edge is the activity of a user on a repo by index, the weights are log-scaled
+// weight is the weight of all the events & contribution the one of the events contributing to the repo
+type edge struct {
+	user         int
+	repo         int
+	weight       float64
+	contribution float64
+}
+
+// Compute computes the influence scores with a PageRank walk between the users who contribute to the same repos
+// the weight of the activity of a user on a repo is log(1 + the sum of the weights of its events on the repo),
+// a user endorses its repos by splitting its score over them by weight & a repo passes the endorsement of a user
+// to its other contributors by contribution weight & by how focused they are on the repo, the rest is lost (jumps to
+// a random user), so a user gains influence from the other influential users of the repos it focuses on, not from
+// the volume of its own events nor from being spread over many repos e.g. a bot, & watching or forking a repo gains nothing
+// the influence of a repo is the endorsement of its users
+func Compute(eventHandler service.EventHandler, options Options) Scores {
+	damping := options.Damping
+	if damping <= 0 {
+		damping = DefaultDamping
+	}
+
+	// the sums of the weights of all the events & of the contributing events, keyed by user & repo
+	sums := make(map[[2]string][2]float64)
+	for _, event := range eventHandler.Events {
+		if event.Actor == nil || event.Repo == nil {
+			continue
+		}
+		key := [2]string{event.Actor.ID, event.Repo.ID}
+		sum := sums[key]
+		sum[0] += options.weight(event.Type)
+		if !endorsingTypes[event.Type] {
+			sum[1] += options.weight(event.Type)
+		}
+		sums[key] = sum
+	}
+
+	// the users & repos are indexed in a fixed order so that the scores do not depend on the order of the events
+	userSet, repoSet := make(map[string]bool), make(map[string]bool)
+	for key, sum := range sums {
+		if sum[0] > 0 {
+			userSet[key[0]] = true
+			repoSet[key[1]] = true
+		}
+	}
+	users, userIndex := indexIDs(userSet)
+	repos, repoIndex := indexIDs(repoSet)
+
+	edges := make([]edge, 0, len(sums))
+	for key, sum := range sums {
+		if sum[0] > 0 {
+			edges = append(edges, edge{user: userIndex[key[0]], repo: repoIndex[key[1]], weight: math.Log1p(sum[0]), contribution: math.Log1p(sum[1])})
+		}
+	}
+	sort.Slice(edges, func(i, j int) bool {
+		if edges[i].user == edges[j].user {
+			return edges[i].repo < edges[j].repo
+		}
+		return edges[i].user < edges[j].user
+	})
+
+	g := newGraph(edges, len(users), len(repos))
+	ranks := g.pageRank(damping)
+	endorsements := g.endorse(ranks)
+	scores := Scores{Users: make(map[string]float64, len(users)), Repos: make(map[string]float64, len(repos))}
+	for i, id := range users {
+		scores.Users[id] = ranks[i] * float64(len(users))
+	}
+	for i, id := range repos {
+		scores.Repos[id] = endorsements[i] * float64(len(repos))
+	}
+	return scores
+}
+
+// indexIDs returns the sorted ids of the set with the index of every id
+func indexIDs(set map[string]bool) ([]string, map[string]int) {
+	ids := make([]string, 0, len(set))
+	for id := range set {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	index := make(map[string]int, len(ids))
+	for i, id := range ids {
+		index[id] = i
+	}
+	return ids, index
+}
+
+// graph is the bipartite graph of the users & repos with the sums of the weights of their edges
+type graph struct {
+	edges             []edge
+	userWeights       []float64
+	userContributions []float64
+	repoContributions []float64
+}
+
+// newGraph creates the graph of the edges between the given number of users & repos
+func newGraph(edges []edge, userCount, repoCount int) *graph {
+	g := &graph{
+		edges:             edges,
+		userWeights:       make([]float64, userCount),
+		userContributions: make([]float64, userCount),
+		repoContributions: make([]float64, repoCount),
+	}
+	for _, e := range edges {
+		g.userWeights[e.user] += e.weight
+		g.userContributions[e.user] += e.contribution
+		g.repoContributions[e.repo] += e.contribution
+	}
+	return g
+}
+
+// endorse returns the endorsement of every repo, i.e. the ranks of its users split over their repos by weight
+func (g *graph) endorse(ranks []float64) []float64 {
+	endorsements := make([]float64, len(g.repoContributions))
+	for _, e := range g.edges {
+		endorsements[e.repo] += ranks[e.user] * e.weight / g.userWeights[e.user]
+	}
+	return endorsements
+}
+
+// pageRank returns the rank of the users walking from a user to one of its repos & from the repo to one of its other
+// contributors, every user has at least one edge & the ranks sum up to 1
+// the walk is not stochastic on purpose: the share of a contributor of a repo is also weighted by its focus on the
+// repo, & the rank flowing back to the same user, to a repo without other contributors or through a watch or a fork
+// is not passed, the rank not passed is spread over all the users like the rank of the dangling nodes of PageRank,
+// so the users spread over many repos or alone on them (e.g. bots) keep only the random jumps instead of their volume
+func (g *graph) pageRank(damping float64) []float64 {
+	n := len(g.userWeights)
+	ranks := make([]float64, n)
+	for i := range ranks {
+		ranks[i] = 1 / float64(n)
+	}
+
+	next := make([]float64, n)
+	for iteration := 0; iteration < maxIterations; iteration++ {
+		// the endorsement of a repo except the part of the same user is passed to its contributors
+		// by their share of the contributions to the repo & their share of contributions on the repo
+		endorsements := g.endorse(ranks)
+		passed := 0.0
+		for i := range next {
+			next[i] = 0
+		}
+		for _, e := range g.edges {
+			if e.contribution == 0 {
+				continue
+			}
+			own := ranks[e.user] * e.weight / g.userWeights[e.user]
+			flow := damping * (endorsements[e.repo] - own) * e.contribution / g.repoContributions[e.repo] * e.contribution / g.userContributions[e.user]
+			next[e.user] += flow
+			passed += flow
+		}
+		// the rank not passed, including the one of the random jumps, is spread over all the users
+		for i := range next {
+			next[i] += (1 - passed) / float64(n)
+		}
+
+		change := 0.0
+		for i := range ranks {
+			change += math.Abs(next[i] - ranks[i])
+		}
+		ranks, next = next, ranks
+		if change < tolerance {
+			break
+		}
+	}
+	return ranks
+}
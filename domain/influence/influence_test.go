@@ -0,0 +1,149 @@
+package influence
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/ameykpatil/github-data-analyzer/db/entities"
+	"github.com/ameykpatil/github-data-analyzer/service"
+	"github.com/stretchr/testify/assert"
+)
+
+// newEventHandler creates the events of the given actor, repo & event type triples
+func newEventHandler(triples [][3]string) service.EventHandler {
+	events := map[string]*service.Event{}
+	for i, triple := range triples {
+		id := strconv.Itoa(i)
+		events[id] = &service.Event{
+			ID:    id,
+			Type:  triple[2],
+			Actor: &entities.Actor{ID: triple[0], Username: "user" + triple[0]},
+			Repo:  &entities.Repo{ID: triple[1], Name: "owner/" + triple[1]},
+		}
+	}
+	return service.EventHandler{DataStore: nil, Events: events}
+}
+
+func TestCompute(t *testing.T) {
+	// users 1 & 2 push to the shared repos a & b, users 3 & 5 push only to their own repos c & d, user 3 with many events
+	eventHandler := newEventHandler([][3]string{
+		{"1", "a", "PushEvent"},
+		{"2", "a", "PushEvent"},
+		{"1", "b", "PushEvent"},
+		{"2", "b", "PushEvent"},
+		{"3", "c", "PushEvent"},
+		{"3", "c", "PushEvent"},
+		{"3", "c", "PushEvent"},
+		{"4", "a", "WatchEvent"},
+		{"5", "d", "PushEvent"},
+	})
+
+	scores := Compute(eventHandler, Options{})
+	assert.Len(t, scores.Users, 5)
+	assert.Len(t, scores.Repos, 4)
+
+	// the mean score of the users & of the repos is 1
+	total := 0.0
+	for _, score := range scores.Users {
+		total += score
+	}
+	assert.InDelta(t, 5, total, 1e-6)
+	total = 0
+	for _, score := range scores.Repos {
+		total += score
+	}
+	assert.InDelta(t, 4, total, 1e-6)
+
+	// the repo with more users is more influential & watching gives less influence than pushing
+	assert.Greater(t, scores.Repos["a"], scores.Repos["b"])
+	assert.Greater(t, scores.Users["1"], scores.Users["4"])
+	assert.InDelta(t, scores.Users["1"], scores.Users["2"], 1e-9)
+
+	// the users alone on their repos gain no influence, whatever the number of their events
+	assert.Less(t, scores.Users["3"], 1.0)
+	assert.InDelta(t, scores.Users["5"], scores.Users["3"], 1e-9)
+}
+
+func TestComputeBot(t *testing.T) {
+	// user x pushes once to the repos s & t shared by many users, the bot pushes 100 times to the repo of owner o
+	// & is alone on other repos
+	triples := [][3]string{{"x", "s", "PushEvent"}, {"x", "t", "PushEvent"}, {"o", "z", "PushEvent"}}
+	for i := 0; i < 5; i++ {
+		triples = append(triples, [3]string{"s" + strconv.Itoa(i), "s", "PushEvent"}, [3]string{"t" + strconv.Itoa(i), "t", "PushEvent"})
+		triples = append(triples, [3]string{"bot", "own" + strconv.Itoa(i), "PushEvent"})
+	}
+	for i := 0; i < 100; i++ {
+		triples = append(triples, [3]string{"bot", "z", "PushEvent"})
+	}
+
+	scores := Compute(newEventHandler(triples), Options{})
+	assert.Greater(t, scores.Users["x"], scores.Users["bot"])
+	assert.Greater(t, scores.Users["x"], scores.Users["s0"])
+	assert.Greater(t, scores.Repos["s"], scores.Repos["z"])
+	assert.Greater(t, scores.Repos["z"], scores.Repos["own0"])
+}
+
+func TestComputeOptions(t *testing.T) {
+	eventHandler := newEventHandler([][3]string{
+		{"1", "a", "PushEvent"},
+		{"2", "a", "WatchEvent"},
+		{"2", "b", "PushEvent"},
+	})
+
+	// ignoring the watch events removes the edge
+	scores := Compute(eventHandler, Options{Weights: map[string]float64{"WatchEvent": 0}})
+	assert.InDelta(t, scores.Users["1"], scores.Users["2"], 1e-9)
+
+	// watching repo a endorses it, so user 1 gains from user 2 but not the other way around
+	light := Compute(eventHandler, Options{})
+	assert.Greater(t, light.Users["1"], light.Users["2"])
+
+	// with a heavier watch edge user 2 endorses repo a more
+	heavy := Compute(eventHandler, Options{Weights: map[string]float64{"WatchEvent": 4}})
+	assert.Greater(t, heavy.Repos["a"]/heavy.Repos["b"], light.Repos["a"]/light.Repos["b"])
+
+	// without damping all the scores are closer to the mean
+	low := Compute(eventHandler, Options{Damping: 0.1})
+	assert.Less(t, low.Users["1"]-low.Users["2"], light.Users["1"]-light.Users["2"])
+
+	assert.Empty(t, Compute(newEventHandler(nil), Options{}).Users)
+}
+
+func TestPageRank(t *testing.T) {
+	// users 0 & 1 share repo 0 where user 1 is less focused, user 2 is alone on repo 1 & only watches repo 0
+	g := newGraph([]edge{
+		{user: 0, repo: 0, weight: 1, contribution: 1},
+		{user: 1, repo: 0, weight: 1, contribution: 1},
+		{user: 1, repo: 1, weight: 1, contribution: 1},
+		{user: 2, repo: 0, weight: 1},
+		{user: 2, repo: 2, weight: 1, contribution: 1},
+	}, 3, 3)
+
+	for _, damping := range []float64{0.1, DefaultDamping} {
+		ranks := g.pageRank(damping)
+
+		// the rank which is not passed is spread over the users, so no rank is lost
+		total := 0.0
+		for _, rank := range ranks {
+			total += rank
+		}
+		assert.InDelta(t, 1, total, 1e-9)
+
+		// user 2 gets only the random jumps as no contributor of its repos passes rank to it
+		assert.Less(t, ranks[2], ranks[1])
+		assert.Less(t, ranks[1], ranks[0])
+	}
+}
+
+func TestParseWeights(t *testing.T) {
+	weights, err := ParseWeights(map[string]string{"WatchEvent": "0.5", "PushEvent": " 2"})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]float64{"WatchEvent": 0.5, "PushEvent": 2}, weights)
+
+	_, err = ParseWeights(map[string]string{"Watch": "1"})
+	assert.Error(t, err)
+	_, err = ParseWeights(map[string]string{"WatchEvent": "-1"})
+	assert.Error(t, err)
+	_, err = ParseWeights(map[string]string{"WatchEvent": "many"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,205 @@
+package message
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/ameykpatil/github-data-analyzer/db/entities"
+	"github.com/ameykpatil/github-data-analyzer/domain/topk"
+	"github.com/ameykpatil/github-data-analyzer/service"
+)
+
+// KeyFunc returns the id & name of the group to which the event belongs, empty id skips the event
+type KeyFunc func(event *service.Event) (string, string)
+
+// ByUser groups the commits by the actor of the event
+func ByUser(event *service.Event) (string, string) {
+	if event.Actor == nil {
+		return "", ""
+	}
+	return event.Actor.ID, event.Actor.Username
+}
+
+// ByRepo groups the commits by the repo of the event
+func ByRepo(event *service.Event) (string, string) {
+	if event.Repo == nil {
+		return "", ""
+	}
+	return event.Repo.ID, event.Repo.Name
+}
+
+// byDataset puts every commit in the same group
+func byDataset(event *service.Event) (string, string) {
+	return "all", "all"
+}
+
+// Options to configure the commit message analysis
+type Options struct {
+	// Terms is the number of most common leading words & n-grams to return
+	Terms int
+	// NGram is the number of words in an n-gram
+	NGram int
+}
+
+// Analyzer encapsulates functionality of analyzing the commit messages
+type Analyzer struct {
+	eventHandler service.EventHandler
+	options      Options
+}
+
+// NewAnalyzer creates a new instance of commit message Analyzer
+func NewAnalyzer(eventHandler service.EventHandler, options Options) *Analyzer {
+	return &Analyzer{
+		eventHandler: eventHandler,
+		options:      options,
+	}
+}
+
+// accumulator collects the commit messages of a group to compute its stats
+type accumulator struct {
+	stats        Stats
+	shas         map[string]bool
+	lengths      []int
+	buckets      []int
+	leadingWords map[string]int
+	ngrams       map[string]int
+}
+
+func newAccumulator(id, name string) *accumulator {
+	return &accumulator{
+		stats:        Stats{ID: id, Name: name},
+		shas:         map[string]bool{},
+		buckets:      make([]int, len(lengthBuckets)+1),
+		leadingWords: map[string]int{},
+		ngrams:       map[string]int{},
+	}
+}
+
+// add accounts the commit in the group, a sha already seen in the group is skipped
+func (a *accumulator) add(commit entities.Commit, n int) {
+	if a.shas[commit.Sha] {
+		return
+	}
+	a.shas[commit.Sha] = true
+
+	kind := Classify(commit.Message)
+	length := len([]rune(Subject(commit.Message)))
+	a.stats.CommitCount++
+	a.lengths = append(a.lengths, length)
+	a.buckets[bucketIndex(length)]++
+	if kind.Conventional {
+		a.stats.ConventionalCount++
+	}
+	if kind.Revert {
+		a.stats.RevertCount++
+	}
+	if kind.Merge {
+		a.stats.MergeCount++
+	}
+	if kind.Placeholder {
+		a.stats.PlaceholderCount++
+	}
+	if kind.Clean {
+		a.stats.CleanCount++
+	}
+
+	// merge commit messages are generated, hence they would only add noise to the terms
+	if kind.Merge {
+		return
+	}
+	words := Words(commit.Message)
+	if len(words) > 0 {
+		a.leadingWords[words[0]]++
+	}
+	seen := map[string]bool{}
+	for i := 0; i+n <= len(words); i++ {
+		ngram := strings.Join(words[i:i+n], " ")
+		if !seen[ngram] {
+			seen[ngram] = true
+			a.ngrams[ngram]++
+		}
+	}
+}
+
+// finalize computes the stats of the group with given number of top terms
+func (a *accumulator) finalize(terms int) Stats {
+	stats := a.stats
+	if len(a.lengths) > 0 {
+		sort.Ints(a.lengths)
+		total := 0
+		for _, length := range a.lengths {
+			total += length
+		}
+		stats.MedianLength = a.lengths[len(a.lengths)/2]
+		stats.MeanLength = float64(total) / float64(len(a.lengths))
+	}
+	for i, count := range a.buckets {
+		stats.Lengths = append(stats.Lengths, Bucket{Label: bucketLabel(i), Count: count})
+	}
+	stats.LeadingWords = topTerms(a.leadingWords, terms)
+	stats.NGrams = topTerms(a.ngrams, terms)
+	return stats
+}
+
+// topTerms returns the given number of most common terms
+func topTerms(counts map[string]int, limit int) []Term {
+	all := make([]Term, 0, len(counts))
+	for text, count := range counts {
+		all = append(all, Term{Text: text, Count: count})
+	}
+	terms := []Term{}
+	for _, i := range topk.Select(termRanking(all), limit, 0) {
+		terms = append(terms, all[i])
+	}
+	return terms
+}
+
+// indexGroups creates map of accumulators (by id) of the groups from the events
+func indexGroups(eventHandler service.EventHandler, key KeyFunc, n int) map[string]*accumulator {
+	groupMap := make(map[string]*accumulator)
+	for _, event := range eventHandler.Events {
+		id, name := key(event)
+		if id == "" || len(event.Commits) == 0 {
+			continue
+		}
+		group, ok := groupMap[id]
+		if !ok {
+			group = newAccumulator(id, name)
+			groupMap[id] = group
+		}
+		for _, commit := range event.Commits {
+			group.add(commit, n)
+		}
+	}
+	return groupMap
+}
+
+// GetStats returns the commit message stats of the whole dataset
+func (ma *Analyzer) GetStats() Stats {
+	groupMap := indexGroups(ma.eventHandler, byDataset, ma.options.NGram)
+	if group, ok := groupMap["all"]; ok {
+		return group.finalize(ma.options.Terms)
+	}
+	return newAccumulator("all", "all").finalize(ma.options.Terms)
+}
+
+// GetGroupStats returns top groups based on provided limit, offset & sort function
+// groups with less than minCommits commits are skipped
+func (ma *Analyzer) GetGroupStats(key KeyFunc, minCommits, limit, offset uint32, fn func(si, sj Stats) bool) []Stats {
+	groupMap := indexGroups(ma.eventHandler, key, ma.options.NGram)
+
+	all := make([]Stats, 0, len(groupMap))
+	for _, group := range groupMap {
+		if group.stats.CommitCount >= int(minCommits) {
+			all = append(all, group.finalize(ma.options.Terms))
+		}
+	}
+
+	indices := topk.Select(statsRanking{stats: all, less: fn}, int(limit), int(offset))
+	stats := make([]Stats, 0, len(indices))
+	for _, i := range indices {
+		stats = append(stats, all[i])
+	}
+
+	return stats
+}
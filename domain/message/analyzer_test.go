@@ -0,0 +1,99 @@
+package message
+
+import (
+	"testing"
+
+	"github.com/ameykpatil/github-data-analyzer/db/entities"
+	"github.com/ameykpatil/github-data-analyzer/service"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	actor1 = entities.Actor{
+		ID:       "111",
+		Username: "Actor1",
+	}
+	actor2 = entities.Actor{
+		ID:       "112",
+		Username: "Actor2",
+	}
+	repo1 = entities.Repo{
+		ID:   "441",
+		Name: "Owner1/Repo1",
+	}
+	repo2 = entities.Repo{
+		ID:   "442",
+		Name: "Owner2/Repo2",
+	}
+	events = map[string]*service.Event{
+		"331": {ID: "331", Type: "PushEvent", Actor: &actor1, Repo: &repo1, Commits: []entities.Commit{
+			{Sha: "221", Message: "fix: handle empty input", EventID: "331"},
+			{Sha: "222", Message: "wip", EventID: "331"},
+		}},
+		// same sha pushed again to another repo
+		"332": {ID: "332", Type: "PushEvent", Actor: &actor1, Repo: &repo2, Commits: []entities.Commit{
+			{Sha: "221", Message: "fix: handle empty input", EventID: "332"},
+		}},
+		"333": {ID: "333", Type: "PushEvent", Actor: &actor2, Repo: &repo2, Commits: []entities.Commit{
+			{Sha: "223", Message: "Merge branch 'main' into feature", EventID: "333"},
+			{Sha: "224", Message: "Handle empty input in parser", EventID: "333"},
+		}},
+		"334": {ID: "334", Type: "WatchEvent", Actor: &actor2, Repo: &repo1},
+	}
+)
+
+func TestGetStats(t *testing.T) {
+	analyzer := NewAnalyzer(service.EventHandler{DataStore: nil, Events: events}, Options{Terms: 2, NGram: 2})
+
+	got := analyzer.GetStats()
+
+	assert.Equal(t, 4, got.CommitCount)
+	assert.Equal(t, 1, got.ConventionalCount)
+	assert.Equal(t, 1, got.MergeCount)
+	assert.Equal(t, 1, got.PlaceholderCount)
+	assert.Equal(t, 3, got.CleanCount)
+	assert.Equal(t, 0.75, got.Hygiene())
+	assert.Equal(t, []Term{{Text: "handle", Count: 2}, {Text: "wip", Count: 1}}, got.LeadingWords)
+	assert.Equal(t, []Term{{Text: "empty input", Count: 2}, {Text: "handle empty", Count: 2}}, got.NGrams)
+}
+
+func TestGetGroupStats(t *testing.T) {
+	analyzer := NewAnalyzer(service.EventHandler{DataStore: nil, Events: events}, Options{Terms: 2, NGram: 2})
+	byHygiene := func(si, sj Stats) bool {
+		return si.Hygiene() > sj.Hygiene()
+	}
+
+	tests := []struct {
+		name       string
+		key        KeyFunc
+		minCommits uint32
+		exp        map[string]int
+	}{
+		{
+			name: "by user",
+			key:  ByUser,
+			exp:  map[string]int{"Actor2": 2, "Actor1": 2},
+		},
+		{
+			name: "by repo",
+			key:  ByRepo,
+			exp:  map[string]int{"Owner2/Repo2": 3, "Owner1/Repo1": 2},
+		},
+		{
+			name:       "by repo with minimum commits",
+			key:        ByRepo,
+			minCommits: 3,
+			exp:        map[string]int{"Owner2/Repo2": 3},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := analyzer.GetGroupStats(tt.key, tt.minCommits, 0, 0, byHygiene)
+			assert.Len(t, got, len(tt.exp))
+			for _, stats := range got {
+				assert.Equal(t, tt.exp[stats.Name], stats.CommitCount)
+			}
+			assert.Equal(t, 1.0, got[0].Hygiene())
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package message
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+var (
+	// conventionalPattern matches Conventional Commits subjects e.g. feat: ..., fix(parser)!: ...
+	conventionalPattern = regexp.MustCompile(`^(feat|fix|docs|style|refactor|perf|test|build|ci|chore|revert)(\([^)]*\))?!?: `)
+	// placeholders are the messages (lower-cased & trimmed) which do not describe the change
+	placeholders = map[string]bool{
+		"": true, ".": true, "-": true, "wip": true, "update": true, "updates": true, "updated": true,
+		"fix": true, "fixes": true, "test": true, "commit": true, "changes": true, "change": true, "minor": true,
+		"stuff": true, "temp": true, "tmp": true, "asdf": true, "save": true, "first commit": true,
+	}
+	// lengthBuckets are the inclusive upper bounds of the subject line lengths
+	lengthBuckets = []int{0, 9, 19, 49, 72}
+)
+
+// Kind of commit message
+type Kind struct {
+	Conventional bool
+	Revert       bool
+	Merge        bool
+	Placeholder  bool
+	// Clean subject line is not a placeholder & has 10 to 72 characters
+	Clean bool
+}
+
+// Subject returns the first line of the commit message
+func Subject(message string) string {
+	if i := strings.IndexByte(message, '\n'); i >= 0 {
+		message = message[:i]
+	}
+	return strings.TrimSpace(message)
+}
+
+// Classify returns the kind of commit message
+func Classify(message string) Kind {
+	subject := Subject(message)
+	length := len([]rune(subject))
+	kind := Kind{
+		Conventional: conventionalPattern.MatchString(subject),
+		Revert:       strings.HasPrefix(subject, "Revert ") || strings.HasPrefix(subject, "revert: "),
+		Merge:        strings.HasPrefix(subject, "Merge "),
+		Placeholder:  placeholders[strings.ToLower(subject)],
+	}
+	kind.Clean = !kind.Placeholder && length >= 10 && length <= 72
+	return kind
+}
+
+// Words returns the lower-cased words of the subject line without the Conventional Commits prefix
+func Words(message string) []string {
+	subject := Subject(message)
+	if loc := conventionalPattern.FindStringIndex(subject); loc != nil {
+		subject = subject[loc[1]:]
+	}
+	fields := strings.FieldsFunc(strings.ToLower(subject), func(r rune) bool {
+		return !unicode.IsLetter(r) && r != '\''
+	})
+	words := make([]string, 0, len(fields))
+	for _, field := range fields {
+		if field = strings.Trim(field, "'"); field != "" {
+			words = append(words, field)
+		}
+	}
+	return words
+}
+
+// bucketIndex returns the index of length bucket, len(lengthBuckets) is the overflow bucket
+func bucketIndex(length int) int {
+	for i, bound := range lengthBuckets {
+		if length <= bound {
+			return i
+		}
+	}
+	return len(lengthBuckets)
+}
+
+// bucketLabel returns the readable label of the length bucket
+func bucketLabel(i int) string {
+	switch {
+	case i == 0:
+		return "0"
+	case i == len(lengthBuckets):
+		return ">" + strconv.Itoa(lengthBuckets[i-1])
+	default:
+		return strconv.Itoa(lengthBuckets[i-1]+1) + "-" + strconv.Itoa(lengthBuckets[i])
+	}
+}
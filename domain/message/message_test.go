@@ -0,0 +1,68 @@
+package message
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		name    string
+		message string
+		exp     Kind
+	}{
+		{
+			name:    "clean message",
+			message: "Refactor member index",
+			exp:     Kind{Clean: true},
+		},
+		{
+			name:    "conventional commit with scope",
+			message: "chore(deps): bump lodash from 4.17.15 to 4.17.19",
+			exp:     Kind{Conventional: true, Clean: true},
+		},
+		{
+			name:    "revert commit",
+			message: "Revert \"Refactor member index\"",
+			exp:     Kind{Revert: true, Clean: true},
+		},
+		{
+			name:    "merge commit",
+			message: "Merge pull request #12 from user/branch\n\nRefactor member index",
+			exp:     Kind{Merge: true, Clean: true},
+		},
+		{
+			name:    "placeholder message",
+			message: " WIP ",
+			exp:     Kind{Placeholder: true},
+		},
+		{
+			name:    "empty message",
+			message: "",
+			exp:     Kind{Placeholder: true},
+		},
+		{
+			name:    "short message",
+			message: "Typo",
+			exp:     Kind{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.exp, Classify(tt.message))
+		})
+	}
+}
+
+func TestWords(t *testing.T) {
+	assert.Equal(t, []string{"add", "user's", "readme", "md"}, Words("feat(docs): Add user's README.md\n\nbody"))
+	assert.Equal(t, []string{}, Words("123"))
+}
+
+func TestBucketLabel(t *testing.T) {
+	assert.Equal(t, "0", bucketLabel(bucketIndex(0)))
+	assert.Equal(t, "1-9", bucketLabel(bucketIndex(5)))
+	assert.Equal(t, "50-72", bucketLabel(bucketIndex(72)))
+	assert.Equal(t, ">72", bucketLabel(bucketIndex(73)))
+}
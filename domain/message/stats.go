@@ -0,0 +1,83 @@
+package message
+
+// Term is a word or n-gram with the number of commit messages containing it
+type Term struct {
+	Text  string
+	Count int
+}
+
+// Bucket is a range of subject line lengths with the number of commit messages in it
+type Bucket struct {
+	Label string
+	Count int
+}
+
+// Stats encapsulates commit message properties of a group of commits e.g. commits of a user or a repo
+// every distinct commit sha is counted once per group
+type Stats struct {
+	ID                string
+	Name              string
+	CommitCount       int
+	MedianLength      int
+	MeanLength        float64
+	Lengths           []Bucket
+	LeadingWords      []Term
+	NGrams            []Term
+	ConventionalCount int
+	RevertCount       int
+	MergeCount        int
+	PlaceholderCount  int
+	CleanCount        int
+}
+
+// Share returns the share of the given count in the commits of the group
+func (s Stats) Share(count int) float64 {
+	if s.CommitCount == 0 {
+		return 0
+	}
+	return float64(count) / float64(s.CommitCount)
+}
+
+// Hygiene is the share of commits with a clean subject line
+func (s Stats) Hygiene() float64 {
+	return s.Share(s.CleanCount)
+}
+
+// statsRanking ranks the stats using the given sort function (topk Interface)
+type statsRanking struct {
+	stats []Stats
+	less  func(i, j Stats) bool
+}
+
+// Len is the number of stats to rank
+func (r statsRanking) Len() int {
+	return len(r.stats)
+}
+
+// Less reports whether the stats i ranks before the stats j
+func (r statsRanking) Less(i, j int) bool {
+	return r.less(r.stats[i], r.stats[j])
+}
+
+// ID is used to break the ties between stats
+func (r statsRanking) ID(i int) string {
+	return r.stats[i].ID
+}
+
+// termRanking ranks the terms by count (topk Interface)
+type termRanking []Term
+
+// Len is the number of terms to rank
+func (r termRanking) Len() int {
+	return len(r)
+}
+
+// Less reports whether the term i ranks before the term j
+func (r termRanking) Less(i, j int) bool {
+	return r[i].Count > r[j].Count
+}
+
+// ID is used to break the ties between terms
+func (r termRanking) ID(i int) string {
+	return r[i].Text
+}
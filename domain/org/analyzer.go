@@ -0,0 +1,80 @@
+package org
+
+import (
+	"github.com/ameykpatil/github-data-analyzer/domain/topk"
+	"github.com/ameykpatil/github-data-analyzer/service"
+)
+
+// Analyzer encapsulates functionality of analyzing the repo owners
+type Analyzer struct {
+	eventHandler service.EventHandler
+	orgMap       map[string]*Org
+}
+
+// NewAnalyzer creates a new instance of org Analyzer
+func NewAnalyzer(eventHandler service.EventHandler) *Analyzer {
+	return &Analyzer{
+		eventHandler: eventHandler,
+		orgMap:       indexOrgs(eventHandler),
+	}
+}
+
+// indexOrgs creates map of orgs from the events, keyed by owner name
+func indexOrgs(eventHandler service.EventHandler) map[string]*Org {
+	orgMap := make(map[string]*Org)
+	// shas, repos & contributors seen so far per org, used to count distinct values
+	orgShas := make(map[string]map[string]bool)
+	orgRepos := make(map[string]map[string]bool)
+	orgContributors := make(map[string]map[string]bool)
+	for _, event := range eventHandler.Events {
+		if event.Repo == nil {
+			continue
+		}
+		name := service.RepoOwner(event.Repo.Name)
+		org, ok := orgMap[name]
+		if !ok {
+			org = &Org{
+				Name:           name,
+				EventTypeCount: map[string]int{},
+			}
+			orgMap[name] = org
+			orgShas[name] = map[string]bool{}
+			orgRepos[name] = map[string]bool{}
+			orgContributors[name] = map[string]bool{}
+		}
+		org.CommitCount = org.CommitCount + len(event.Commits)
+		for _, commit := range event.Commits {
+			if !orgShas[name][commit.Sha] {
+				orgShas[name][commit.Sha] = true
+				org.DistinctCommitCount++
+			}
+		}
+		org.EventTypeCount[event.Type] = org.EventTypeCount[event.Type] + 1
+		if !orgRepos[name][event.Repo.ID] {
+			orgRepos[name][event.Repo.ID] = true
+			org.RepoCount++
+		}
+		if event.Actor != nil && !orgContributors[name][event.Actor.ID] {
+			orgContributors[name][event.Actor.ID] = true
+			org.ContributorCount++
+		}
+	}
+	return orgMap
+}
+
+// GetTopOrgs returns top orgs based on provided limit, offset & sort function
+// limit 0 returns all the orgs, ties are broken by the name
+func (oa *Analyzer) GetTopOrgs(limit, offset uint32, fn func(oi, oj Org) bool) []Org {
+	all := make([]Org, 0, len(oa.orgMap))
+	for _, org := range oa.orgMap {
+		all = append(all, *org)
+	}
+
+	indices := topk.Select(orgRanking{orgs: all, less: fn}, int(limit), int(offset))
+	orgs := make([]Org, 0, len(indices))
+	for _, i := range indices {
+		orgs = append(orgs, all[i])
+	}
+
+	return orgs
+}
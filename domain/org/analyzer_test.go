@@ -0,0 +1,125 @@
+package org
+
+import (
+	"testing"
+
+	"github.com/ameykpatil/github-data-analyzer/db/entities"
+	"github.com/ameykpatil/github-data-analyzer/service"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	actor1 = entities.Actor{
+		ID:       "111",
+		Username: "Actor1",
+	}
+	actor2 = entities.Actor{
+		ID:       "112",
+		Username: "Actor2",
+	}
+	commit1 = entities.Commit{
+		Sha:     "221",
+		Message: "Message 1",
+		EventID: "331",
+	}
+	commit2 = entities.Commit{
+		Sha:     "222",
+		Message: "Message 2",
+		EventID: "332",
+	}
+	commit3 = entities.Commit{
+		Sha:     "221",
+		Message: "Message 1",
+		EventID: "333",
+	}
+	event1 = entities.Event{
+		ID:      "331",
+		Type:    "PushEvent",
+		ActorID: "111",
+		RepoID:  "441",
+	}
+	event2 = entities.Event{
+		ID:      "332",
+		Type:    "PushEvent",
+		ActorID: "112",
+		RepoID:  "442",
+	}
+	event3 = entities.Event{
+		ID:      "333",
+		Type:    "PushEvent",
+		ActorID: "112",
+		RepoID:  "443",
+	}
+	event4 = entities.Event{
+		ID:      "334",
+		Type:    "WatchEvent",
+		ActorID: "111",
+		RepoID:  "443",
+	}
+	repo1 = entities.Repo{
+		ID:   "441",
+		Name: "Owner1/Repo1",
+	}
+	repo2 = entities.Repo{
+		ID:   "442",
+		Name: "Owner2/Repo2",
+	}
+	repo3 = entities.Repo{
+		ID:   "443",
+		Name: "Owner2/Repo3",
+	}
+)
+
+func TestIndexOrgs(t *testing.T) {
+
+	tests := []struct {
+		name   string
+		events map[string]*service.Event
+		exp    map[string]*Org
+	}{
+		{
+			name: "single repo per org",
+			events: map[string]*service.Event{
+				event1.ID: {ID: event1.ID, Type: event1.Type, Actor: &actor1, Repo: &repo1, Commits: []entities.Commit{commit1}},
+				event2.ID: {ID: event2.ID, Type: event2.Type, Actor: &actor2, Repo: &repo2, Commits: []entities.Commit{commit2}},
+			},
+			exp: map[string]*Org{
+				"Owner1": {Name: "Owner1", CommitCount: 1, DistinctCommitCount: 1, EventTypeCount: map[string]int{"PushEvent": 1}, RepoCount: 1, ContributorCount: 1},
+				"Owner2": {Name: "Owner2", CommitCount: 1, DistinctCommitCount: 1, EventTypeCount: map[string]int{"PushEvent": 1}, RepoCount: 1, ContributorCount: 1},
+			},
+		},
+		{
+			name: "multiple repos & contributors per org",
+			events: map[string]*service.Event{
+				event1.ID: {ID: event1.ID, Type: event1.Type, Actor: &actor1, Repo: &repo1, Commits: []entities.Commit{commit1}},
+				event2.ID: {ID: event2.ID, Type: event2.Type, Actor: &actor2, Repo: &repo2, Commits: []entities.Commit{commit2}},
+				event3.ID: {ID: event3.ID, Type: event3.Type, Actor: &actor2, Repo: &repo3, Commits: []entities.Commit{commit3}},
+				event4.ID: {ID: event4.ID, Type: event4.Type, Actor: &actor1, Repo: &repo3, Commits: []entities.Commit{}},
+			},
+			exp: map[string]*Org{
+				"Owner1": {Name: "Owner1", CommitCount: 1, DistinctCommitCount: 1, EventTypeCount: map[string]int{"PushEvent": 1}, RepoCount: 1, ContributorCount: 1},
+				"Owner2": {Name: "Owner2", CommitCount: 2, DistinctCommitCount: 2, EventTypeCount: map[string]int{"PushEvent": 2, "WatchEvent": 1}, RepoCount: 2, ContributorCount: 2},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			eventHandler := service.EventHandler{DataStore: nil, Events: tt.events}
+			got := indexOrgs(eventHandler)
+			assert.Len(t, got, len(tt.exp))
+			for k, v := range tt.exp {
+				if gotOrg, ok := got[k]; ok {
+					assert.Equal(t, v.Name, gotOrg.Name)
+					assert.Equal(t, v.CommitCount, gotOrg.CommitCount)
+					assert.Equal(t, v.DistinctCommitCount, gotOrg.DistinctCommitCount)
+					assert.EqualValues(t, v.EventTypeCount, gotOrg.EventTypeCount)
+					assert.Equal(t, v.RepoCount, gotOrg.RepoCount)
+					assert.Equal(t, v.ContributorCount, gotOrg.ContributorCount)
+				} else {
+					t.Errorf("expected org with name %s not in the result", k)
+				}
+			}
+		})
+	}
+
+}
@@ -0,0 +1,33 @@
+package org
+
+// Org encapsulates required properties related to repo owner (user or organization)
+// CommitCount counts every pushed commit whereas DistinctCommitCount counts unique shas
+type Org struct {
+	Name                string
+	CommitCount         int
+	DistinctCommitCount int
+	EventTypeCount      map[string]int
+	RepoCount           int
+	ContributorCount    int
+}
+
+// orgRanking ranks the orgs using the given sort function (topk Interface)
+type orgRanking struct {
+	orgs []Org
+	less func(i, j Org) bool
+}
+
+// Len is the number of orgs to rank
+func (r orgRanking) Len() int {
+	return len(r.orgs)
+}
+
+// Less reports whether the org i ranks before the org j
+func (r orgRanking) Less(i, j int) bool {
+	return r.less(r.orgs[i], r.orgs[j])
+}
+
+// ID is used to break the ties between orgs
+func (r orgRanking) ID(i int) string {
+	return r.orgs[i].Name
+}
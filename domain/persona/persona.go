@@ -0,0 +1,113 @@
+// Package persona classifies the users into activity personas based on the mix of their event types
+package persona
+
+import (
+	"errors"
+
+	"github.com/ameykpatil/github-data-analyzer/domain/user"
+)
+
+// Persona is the label of the kind of activity of a user
+type Persona string
+
+// personas of the users, Other is the one of the users matching none of the thresholds
+const (
+	Coder      Persona = "coder"
+	Reviewer   Persona = "reviewer"
+	Triager    Persona = "triager"
+	Stargazer  Persona = "stargazer"
+	Maintainer Persona = "maintainer"
+	Other      Persona = "other"
+)
+
+// Personas are all the personas, in the order used to break the ties between them
+var Personas = []Persona{Coder, Reviewer, Triager, Maintainer, Stargazer, Other}
+
+// eventTypes are the event types counted for each persona
+var eventTypes = map[Persona][]string{
+	Coder:      {"PushEvent"},
+	Reviewer:   {"PullRequestReviewCommentEvent", "PullRequestReviewEvent"},
+	Triager:    {"IssuesEvent", "IssueCommentEvent"},
+	Maintainer: {"ReleaseEvent", "MemberEvent", "CreateEvent"},
+	Stargazer:  {"WatchEvent", "ForkEvent"},
+}
+
+// Thresholds are the minimum shares of the events of a user of the event types of each persona
+// the default stargazer threshold of 1 means only watch & fork events
+type Thresholds struct {
+	Coder      float64
+	Reviewer   float64
+	Triager    float64
+	Maintainer float64
+	Stargazer  float64
+}
+
+// DefaultThresholds are the thresholds used unless configured
+var DefaultThresholds = Thresholds{
+	Coder:      0.5,
+	Reviewer:   0.2,
+	Triager:    0.3,
+	Maintainer: 0.3,
+	Stargazer:  1,
+}
+
+// Validate returns an error if any of the thresholds is not greater than 0 & at most 1
+func (t Thresholds) Validate() error {
+	for _, threshold := range []float64{t.Coder, t.Reviewer, t.Triager, t.Maintainer, t.Stargazer} {
+		if threshold <= 0 || threshold > 1 {
+			return errors.New("persona thresholds should be greater than 0 & at most 1")
+		}
+	}
+	return nil
+}
+
+// of returns the threshold of the persona
+func (t Thresholds) of(p Persona) float64 {
+	switch p {
+	case Coder:
+		return t.Coder
+	case Reviewer:
+		return t.Reviewer
+	case Triager:
+		return t.Triager
+	case Maintainer:
+		return t.Maintainer
+	default:
+		return t.Stargazer
+	}
+}
+
+// Parse returns the persona with the given name
+func Parse(name string) (Persona, error) {
+	for _, p := range Personas {
+		if string(p) == name {
+			return p, nil
+		}
+	}
+	return "", errors.New("invalid persona " + name)
+}
+
+// Classify returns the persona of the user, i.e. among the personas whose share of the events reaches its threshold
+// the one with the highest share, ties are broken by the order of Personas & Other is returned if none reaches it
+func Classify(u user.User, thresholds Thresholds) Persona {
+	events := 0
+	for _, count := range u.EventTypeCount {
+		events += count
+	}
+	if events == 0 {
+		return Other
+	}
+
+	best, bestShare := Other, 0.0
+	for _, p := range Personas {
+		count := 0
+		for _, eventType := range eventTypes[p] {
+			count += u.EventTypeCount[eventType]
+		}
+		share := float64(count) / float64(events)
+		if count > 0 && share >= thresholds.of(p) && share > bestShare {
+			best, bestShare = p, share
+		}
+	}
+	return best
+}
@@ -0,0 +1,97 @@
+package persona
+
+import (
+	"testing"
+
+	"github.com/ameykpatil/github-data-analyzer/domain/user"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		name       string
+		counts     map[string]int
+		thresholds Thresholds
+		exp        Persona
+	}{
+		{
+			name:       "push heavy user is a coder",
+			counts:     map[string]int{"PushEvent": 8, "CreateEvent": 2},
+			thresholds: DefaultThresholds,
+			exp:        Coder,
+		},
+		{
+			name:       "review comments make a reviewer",
+			counts:     map[string]int{"PushEvent": 2, "PullRequestReviewCommentEvent": 3},
+			thresholds: DefaultThresholds,
+			exp:        Reviewer,
+		},
+		{
+			name:       "issues & comments make a triager",
+			counts:     map[string]int{"IssuesEvent": 2, "IssueCommentEvent": 3, "PushEvent": 1},
+			thresholds: DefaultThresholds,
+			exp:        Triager,
+		},
+		{
+			name:       "only watch & fork events make a stargazer",
+			counts:     map[string]int{"WatchEvent": 3, "ForkEvent": 1},
+			thresholds: DefaultThresholds,
+			exp:        Stargazer,
+		},
+		{
+			name:       "mostly watch events are not a stargazer by default",
+			counts:     map[string]int{"WatchEvent": 3, "PullRequestEvent": 1},
+			thresholds: DefaultThresholds,
+			exp:        Other,
+		},
+		{
+			name:       "mostly watch events are a stargazer with lower threshold",
+			counts:     map[string]int{"WatchEvent": 3, "PullRequestEvent": 1},
+			thresholds: Thresholds{Coder: 0.5, Reviewer: 0.2, Triager: 0.3, Maintainer: 0.3, Stargazer: 0.7},
+			exp:        Stargazer,
+		},
+		{
+			name:       "releases & members make a maintainer",
+			counts:     map[string]int{"ReleaseEvent": 2, "MemberEvent": 1, "CreateEvent": 2, "PushEvent": 2},
+			thresholds: DefaultThresholds,
+			exp:        Maintainer,
+		},
+		{
+			name:       "tie is broken by the order of personas",
+			counts:     map[string]int{"PushEvent": 1, "IssuesEvent": 1},
+			thresholds: DefaultThresholds,
+			exp:        Coder,
+		},
+		{
+			name:       "no events",
+			counts:     map[string]int{},
+			thresholds: DefaultThresholds,
+			exp:        Other,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.exp, Classify(user.User{EventTypeCount: tt.counts}, tt.thresholds))
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	p, err := Parse("triager")
+	assert.NoError(t, err)
+	assert.Equal(t, Triager, p)
+
+	_, err = Parse("Coder")
+	assert.Error(t, err)
+}
+
+func TestThresholdsValidate(t *testing.T) {
+	assert.NoError(t, DefaultThresholds.Validate())
+
+	thresholds := DefaultThresholds
+	thresholds.Reviewer = 0
+	assert.Error(t, thresholds.Validate())
+	thresholds.Reviewer = 1.5
+	assert.Error(t, thresholds.Validate())
+}
@@ -0,0 +1,38 @@
+package persona
+
+import "github.com/ameykpatil/github-data-analyzer/domain/user"
+
+// Segment encapsulates the users of a persona, Share is the percentage of all the users
+type Segment struct {
+	Persona Persona
+	Count   int
+	Share   float64
+	Events  int
+	Commits int
+}
+
+// GetSegments returns the segment of every persona in the order of Personas, including the empty ones
+func GetSegments(users []user.User, thresholds Thresholds) []Segment {
+	segmentMap := make(map[Persona]*Segment, len(Personas))
+	for _, p := range Personas {
+		segmentMap[p] = &Segment{Persona: p}
+	}
+	for _, u := range users {
+		segment := segmentMap[Classify(u, thresholds)]
+		segment.Count++
+		segment.Commits += u.CommitCount
+		for _, count := range u.EventTypeCount {
+			segment.Events += count
+		}
+	}
+
+	segments := make([]Segment, 0, len(Personas))
+	for _, p := range Personas {
+		segment := *segmentMap[p]
+		if len(users) > 0 {
+			segment.Share = 100 * float64(segment.Count) / float64(len(users))
+		}
+		segments = append(segments, segment)
+	}
+	return segments
+}
@@ -0,0 +1,26 @@
+package persona
+
+import (
+	"testing"
+
+	"github.com/ameykpatil/github-data-analyzer/domain/user"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetSegments(t *testing.T) {
+	users := []user.User{
+		{ID: "1", CommitCount: 5, EventTypeCount: map[string]int{"PushEvent": 3}},
+		{ID: "2", CommitCount: 1, EventTypeCount: map[string]int{"PushEvent": 1}},
+		{ID: "3", EventTypeCount: map[string]int{"WatchEvent": 2}},
+		{ID: "4", EventTypeCount: map[string]int{"GollumEvent": 1}},
+	}
+
+	got := GetSegments(users, DefaultThresholds)
+	assert.Len(t, got, len(Personas))
+	assert.Equal(t, Segment{Persona: Coder, Count: 2, Share: 50, Events: 4, Commits: 6}, got[0])
+	assert.Equal(t, Segment{Persona: Reviewer}, got[1])
+	assert.Equal(t, Segment{Persona: Stargazer, Count: 1, Share: 25, Events: 2}, got[4])
+	assert.Equal(t, Segment{Persona: Other, Count: 1, Share: 25, Events: 1}, got[5])
+
+	assert.Equal(t, Segment{Persona: Coder}, GetSegments(nil, DefaultThresholds)[0])
+}
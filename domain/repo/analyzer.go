@@ -1,28 +1,71 @@
 package repo
 
 import (
-	"container/heap"
-
+	"github.com/ameykpatil/github-data-analyzer/domain/topk"
 	"github.com/ameykpatil/github-data-analyzer/service"
 )
 
+// Options to configure the repo analysis
+type Options struct {
+	// BusFactorShare is the share of commits the bus factor users should account for, 0 means DefaultBusFactorShare
+	BusFactorShare float64
+	// MinCommits is the minimum number of commits for a repo to be ranked
+	MinCommits int
+}
+
 // Analyzer encapsulates functionality of analyzing the repos
 type Analyzer struct {
-	eventHandler service.EventHandler
-	repoMap      map[string]*Repo
+	eventHandler   service.EventHandler
+	options        Options
+	repoMap        map[string]*Repo
+	contributorMap map[string]map[string]*Contributor
 }
 
 // NewAnalyzer creates a new instance of repo Analyzer
-func NewAnalyzer(eventHandler service.EventHandler) *Analyzer {
+func NewAnalyzer(eventHandler service.EventHandler, options Options) *Analyzer {
+	if options.BusFactorShare <= 0 {
+		options.BusFactorShare = DefaultBusFactorShare
+	}
+	contributorMap := indexContributors(eventHandler)
 	return &Analyzer{
-		eventHandler: eventHandler,
-		repoMap:      indexRepos(eventHandler),
+		eventHandler:   eventHandler,
+		options:        options,
+		repoMap:        indexRepos(eventHandler, contributorMap, options.BusFactorShare),
+		contributorMap: contributorMap,
+	}
+}
+
+// indexContributors creates map of contributors (by user id) per repo (by repo id) from the events
+func indexContributors(eventHandler service.EventHandler) map[string]map[string]*Contributor {
+	contributorMap := make(map[string]map[string]*Contributor)
+	for _, event := range eventHandler.Events {
+		if event.Repo == nil || event.Actor == nil {
+			continue
+		}
+		if contributorMap[event.Repo.ID] == nil {
+			contributorMap[event.Repo.ID] = map[string]*Contributor{}
+		}
+		contributor, ok := contributorMap[event.Repo.ID][event.Actor.ID]
+		if !ok {
+			contributor = &Contributor{
+				ID:             event.Actor.ID,
+				Username:       event.Actor.Username,
+				EventTypeCount: map[string]int{},
+			}
+			contributorMap[event.Repo.ID][contributor.ID] = contributor
+		}
+		contributor.CommitCount = contributor.CommitCount + len(event.Commits)
+		contributor.EventCount++
+		contributor.EventTypeCount[event.Type] = contributor.EventTypeCount[event.Type] + 1
 	}
+	return contributorMap
 }
 
-// indexRepos creates map of repo from the events
-func indexRepos(eventHandler service.EventHandler) map[string]*Repo {
+// indexRepos creates map of repo from the events, the contributors are used to compute the concentration
+func indexRepos(eventHandler service.EventHandler, contributorMap map[string]map[string]*Contributor, busFactorShare float64) map[string]*Repo {
 	repoMap := make(map[string]*Repo)
+	// shas seen so far per repo, used to count distinct commits
+	repoShas := make(map[string]map[string]bool)
 	for _, event := range eventHandler.Events {
 		if event.Repo == nil {
 			continue
@@ -32,28 +75,165 @@ func indexRepos(eventHandler service.EventHandler) map[string]*Repo {
 			repo = &Repo{
 				ID:             event.Repo.ID,
 				Name:           event.Repo.Name,
+				Owner:          service.RepoOwner(event.Repo.Name),
 				EventTypeCount: map[string]int{},
 			}
 			repoMap[repo.ID] = repo
+			repoShas[repo.ID] = map[string]bool{}
 		}
 		repo.CommitCount = repo.CommitCount + len(event.Commits)
+		for _, commit := range event.Commits {
+			if !repoShas[repo.ID][commit.Sha] {
+				repoShas[repo.ID][commit.Sha] = true
+				repo.DistinctCommitCount++
+			}
+		}
 		repo.EventTypeCount[event.Type] = repo.EventTypeCount[event.Type] + 1
 	}
+
+	for id, repo := range repoMap {
+		commits := make([]int, 0, len(contributorMap[id]))
+		for _, contributor := range contributorMap[id] {
+			if contributor.CommitCount > 0 {
+				commits = append(commits, contributor.CommitCount)
+			}
+		}
+		repo.ContributorCount = len(contributorMap[id])
+		repo.EventTypeActorCount = map[string]int{}
+		for _, contributor := range contributorMap[id] {
+			for eventType := range contributor.EventTypeCount {
+				repo.EventTypeActorCount[eventType] = repo.EventTypeActorCount[eventType] + 1
+			}
+		}
+		repo.BusFactor = busFactor(commits, busFactorShare)
+		repo.Gini = gini(commits)
+		repo.Herfindahl = herfindahl(commits)
+	}
 	return repoMap
 }
 
-// GetTopRepos returns top repos based on provided limit & sort function
-func (ra *Analyzer) GetTopRepos(limit uint32, fn func(ri, rj Repo) bool) []Repo {
-	h := &repoHeap{less: fn}
-	heap.Init(h)
+// ForEachContributor calls fn with the id of every repo & each of its contributors, e.g. to derive other indexes
+// without another pass over the events
+func (ra *Analyzer) ForEachContributor(fn func(repoID string, contributor Contributor)) {
+	for repoID, contributors := range ra.contributorMap {
+		for _, contributor := range contributors {
+			fn(repoID, *contributor)
+		}
+	}
+}
+
+// SetInfluence sets the influence scores (by repo id) of the repos, the repos without a score get 0
+func (ra *Analyzer) SetInfluence(scores map[string]float64) {
+	for id, repo := range ra.repoMap {
+		repo.Influence = scores[id]
+	}
+}
+
+// GetTopRepos returns top repos based on provided limit, offset & sort function
+// limit 0 returns all the repos, ties are broken by the id & repos with less than MinCommits are skipped
+func (ra *Analyzer) GetTopRepos(limit, offset uint32, fn func(ri, rj Repo) bool) []Repo {
+	ranked := ra.GetRankedRepos(limit, offset, fn, topk.Options{})
+	repos := make([]Repo, 0, len(ranked))
+	for _, r := range ranked {
+		repos = append(repos, r.Repo)
+	}
+	return repos
+}
+
+// GetRankedRepos returns top repos with their rank based on provided limit, offset, sort function & ranking options
+// limit 0 returns all the repos & repos with less than MinCommits are skipped
+func (ra *Analyzer) GetRankedRepos(limit, offset uint32, fn func(ri, rj Repo) bool, options topk.Options) []RankedRepo {
+	all := make([]Repo, 0, len(ra.repoMap))
 	for _, repo := range ra.repoMap {
-		heap.Push(h, *repo)
+		if repo.CommitCount >= ra.options.MinCommits {
+			all = append(all, *repo)
+		}
 	}
 
-	var repos []Repo
-	for i := uint32(0); i < limit; i++ {
-		repos = append(repos, heap.Pop(h).(Repo))
+	ranking := repoRanking{repos: all, less: fn, tieBreaker: options.TieBreaker}
+	ranked := topk.SelectRanked(ranking, int(limit), int(offset), options.Mode)
+	repos := make([]RankedRepo, 0, len(ranked))
+	for _, r := range ranked {
+		repos = append(repos, RankedRepo{Repo: all[r.Index], Rank: r.Rank, Tie: r.Tie})
 	}
 
 	return repos
 }
+
+// GetRepos returns all the repos ordered by id
+func (ra *Analyzer) GetRepos() []Repo {
+	return ra.GetTopRepos(0, 0, func(ri, rj Repo) bool {
+		return false
+	})
+}
+
+// GetRepo returns the repo with the given id or name (owner/name)
+// if multiple repos have the same name (e.g. renamed repos), the one with smallest id is returned
+func (ra *Analyzer) GetRepo(idOrName string) (Repo, bool) {
+	if repo, ok := ra.repoMap[idOrName]; ok {
+		return *repo, true
+	}
+	var found *Repo
+	for _, repo := range ra.repoMap {
+		if repo.Name == idOrName && (found == nil || repo.ID < found.ID) {
+			found = repo
+		}
+	}
+	if found == nil {
+		return Repo{}, false
+	}
+	return *found, true
+}
+
+// GetRank returns the rank of the given repo among the ranked repos (with at least MinCommits) based on provided sort
+// function, the percentile is over the same repos as the rank
+func (ra *Analyzer) GetRank(repo Repo, fn func(ri, rj Repo) bool) Rank {
+	total, before, others := 0, 0, 0
+	for _, other := range ra.repoMap {
+		if other.CommitCount < ra.options.MinCommits {
+			continue
+		}
+		total++
+		if other.ID == repo.ID {
+			continue
+		}
+		others++
+		if fn(*other, repo) {
+			before++
+		}
+	}
+	rank := Rank{Rank: before + 1, Total: total}
+	if total > 0 {
+		rank.Percentile = 100 * float64(others-before) / float64(total)
+	}
+	return rank
+}
+
+// GetTopContributors returns top contributors of the repo by commits & then events based on provided limit
+func (ra *Analyzer) GetTopContributors(repoID string, limit uint32) []Contributor {
+	return ra.GetTopContributorsPerRepo([]string{repoID}, limit)[repoID]
+}
+
+// GetTopContributorsPerRepo returns top contributors of each of the given repos by commits & then events, keyed by the repo id
+// limit is the number of contributors per repo, 0 returns all, the indexed contributors are ranked in a single pass
+func (ra *Analyzer) GetTopContributorsPerRepo(repoIDs []string, limit uint32) map[string][]Contributor {
+	ranking := groupedContributorRanking{}
+	for _, repoID := range repoIDs {
+		for _, contributor := range ra.contributorMap[repoID] {
+			ranking.contributors = append(ranking.contributors, *contributor)
+			ranking.repoIDs = append(ranking.repoIDs, repoID)
+		}
+	}
+
+	groups := topk.SelectGrouped(ranking, int(limit))
+	contributorsPerRepo := make(map[string][]Contributor, len(repoIDs))
+	for _, repoID := range repoIDs {
+		contributors := make([]Contributor, 0, len(groups[repoID]))
+		for _, i := range groups[repoID] {
+			contributors = append(contributors, ranking.contributors[i])
+		}
+		contributorsPerRepo[repoID] = contributors
+	}
+
+	return contributorsPerRepo
+}
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/ameykpatil/github-data-analyzer/db/entities"
+	"github.com/ameykpatil/github-data-analyzer/domain/topk"
 	"github.com/ameykpatil/github-data-analyzer/service"
 	"github.com/stretchr/testify/assert"
 )
@@ -32,6 +33,11 @@ var (
 		Message: "Message 3",
 		EventID: "332",
 	}
+	commit4 = entities.Commit{
+		Sha:     "223",
+		Message: "Message 3",
+		EventID: "333",
+	}
 	event1 = entities.Event{
 		ID:      "331",
 		Type:    "PushEvent",
@@ -58,11 +64,11 @@ var (
 	}
 	repo1 = entities.Repo{
 		ID:   "441",
-		Name: "Repo1",
+		Name: "Owner1/Repo1",
 	}
 	repo2 = entities.Repo{
 		ID:   "442",
-		Name: "Repo2",
+		Name: "Owner2/Repo2",
 	}
 )
 
@@ -76,48 +82,62 @@ func TestIndexRepos(t *testing.T) {
 		{
 			name: "single commit & event for repo",
 			events: map[string]*service.Event{
-				event1.ID: {event1.ID, event1.Type, &actor1, &repo1, []entities.Commit{commit1}},
-				event2.ID: {event2.ID, event2.Type, &actor2, &repo2, []entities.Commit{commit2}},
+				event1.ID: {ID: event1.ID, Type: event1.Type, Actor: &actor1, Repo: &repo1, Commits: []entities.Commit{commit1}},
+				event2.ID: {ID: event2.ID, Type: event2.Type, Actor: &actor2, Repo: &repo2, Commits: []entities.Commit{commit2}},
 			},
 			exp: map[string]*Repo{
-				repo1.ID: {repo1.ID, repo1.Name, 1, map[string]int{"PushEvent": 1}},
-				repo2.ID: {repo2.ID, repo2.Name, 1, map[string]int{"CreateEvent": 1}},
+				repo1.ID: {ID: repo1.ID, Name: repo1.Name, Owner: "Owner1", CommitCount: 1, DistinctCommitCount: 1, EventTypeCount: map[string]int{"PushEvent": 1}},
+				repo2.ID: {ID: repo2.ID, Name: repo2.Name, Owner: "Owner2", CommitCount: 1, DistinctCommitCount: 1, EventTypeCount: map[string]int{"CreateEvent": 1}},
 			},
 		},
 		{
 			name: "multiple commits for repo",
 			events: map[string]*service.Event{
-				event1.ID: {event1.ID, event1.Type, &actor1, &repo1, []entities.Commit{commit1}},
-				event2.ID: {event2.ID, event2.Type, &actor2, &repo2, []entities.Commit{commit2, commit3}},
+				event1.ID: {ID: event1.ID, Type: event1.Type, Actor: &actor1, Repo: &repo1, Commits: []entities.Commit{commit1}},
+				event2.ID: {ID: event2.ID, Type: event2.Type, Actor: &actor2, Repo: &repo2, Commits: []entities.Commit{commit2, commit3}},
 			},
 			exp: map[string]*Repo{
-				repo1.ID: {repo1.ID, repo1.Name, 1, map[string]int{"PushEvent": 1}},
-				repo2.ID: {repo2.ID, repo2.Name, 2, map[string]int{"CreateEvent": 1}},
+				repo1.ID: {ID: repo1.ID, Name: repo1.Name, Owner: "Owner1", CommitCount: 1, DistinctCommitCount: 1, EventTypeCount: map[string]int{"PushEvent": 1}},
+				repo2.ID: {ID: repo2.ID, Name: repo2.Name, Owner: "Owner2", CommitCount: 2, DistinctCommitCount: 2, EventTypeCount: map[string]int{"CreateEvent": 1}},
 			},
 		},
 		{
 			name: "multiple events for repo",
 			events: map[string]*service.Event{
-				event1.ID: {event1.ID, event1.Type, &actor1, &repo1, []entities.Commit{commit1}},
-				event2.ID: {event2.ID, event2.Type, &actor2, &repo2, []entities.Commit{commit2, commit3}},
-				event3.ID: {event3.ID, event3.Type, &actor2, &repo2, []entities.Commit{}},
-				event4.ID: {event4.ID, event4.Type, &actor2, &repo2, []entities.Commit{}},
+				event1.ID: {ID: event1.ID, Type: event1.Type, Actor: &actor1, Repo: &repo1, Commits: []entities.Commit{commit1}},
+				event2.ID: {ID: event2.ID, Type: event2.Type, Actor: &actor2, Repo: &repo2, Commits: []entities.Commit{commit2, commit3}},
+				event3.ID: {ID: event3.ID, Type: event3.Type, Actor: &actor2, Repo: &repo2, Commits: []entities.Commit{}},
+				event4.ID: {ID: event4.ID, Type: event4.Type, Actor: &actor2, Repo: &repo2, Commits: []entities.Commit{}},
+			},
+			exp: map[string]*Repo{
+				repo1.ID: {ID: repo1.ID, Name: repo1.Name, Owner: "Owner1", CommitCount: 1, DistinctCommitCount: 1, EventTypeCount: map[string]int{"PushEvent": 1}},
+				repo2.ID: {ID: repo2.ID, Name: repo2.Name, Owner: "Owner2", CommitCount: 2, DistinctCommitCount: 2, EventTypeCount: map[string]int{"CreateEvent": 2, "WatchEvent": 1}},
+			},
+		},
+		{
+			name: "same commit pushed multiple times",
+			events: map[string]*service.Event{
+				event1.ID: {ID: event1.ID, Type: event1.Type, Actor: &actor1, Repo: &repo1, Commits: []entities.Commit{commit1}},
+				event2.ID: {ID: event2.ID, Type: event2.Type, Actor: &actor2, Repo: &repo2, Commits: []entities.Commit{commit2, commit3}},
+				event3.ID: {ID: event3.ID, Type: event3.Type, Actor: &actor2, Repo: &repo2, Commits: []entities.Commit{commit4}},
 			},
 			exp: map[string]*Repo{
-				repo1.ID: {repo1.ID, repo1.Name, 1, map[string]int{"PushEvent": 1}},
-				repo2.ID: {repo2.ID, repo2.Name, 2, map[string]int{"CreateEvent": 2, "WatchEvent": 1}},
+				repo1.ID: {ID: repo1.ID, Name: repo1.Name, Owner: "Owner1", CommitCount: 1, DistinctCommitCount: 1, EventTypeCount: map[string]int{"PushEvent": 1}},
+				repo2.ID: {ID: repo2.ID, Name: repo2.Name, Owner: "Owner2", CommitCount: 3, DistinctCommitCount: 2, EventTypeCount: map[string]int{"CreateEvent": 2}},
 			},
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			eventHandler := service.EventHandler{DataStore: nil, Events: tt.events}
-			got := indexRepos(eventHandler)
+			got := indexRepos(eventHandler, indexContributors(eventHandler), DefaultBusFactorShare)
 			for k, v := range tt.exp {
 				if gotRepo, ok := got[k]; ok {
 					assert.Equal(t, v.ID, gotRepo.ID)
 					assert.Equal(t, v.Name, gotRepo.Name)
+					assert.Equal(t, v.Owner, gotRepo.Owner)
 					assert.Equal(t, v.CommitCount, gotRepo.CommitCount)
+					assert.Equal(t, v.DistinctCommitCount, gotRepo.DistinctCommitCount)
 					assert.EqualValues(t, v.EventTypeCount, gotRepo.EventTypeCount)
 				} else {
 					t.Errorf("expected repo with id %s not in the result", k)
@@ -127,3 +147,246 @@ func TestIndexRepos(t *testing.T) {
 	}
 
 }
+
+func TestGetTopRepos(t *testing.T) {
+	analyzer := &Analyzer{
+		repoMap: map[string]*Repo{
+			"441": {ID: "441", Name: "Owner1/Repo1", CommitCount: 3},
+			"442": {ID: "442", Name: "Owner2/Repo2", CommitCount: 5},
+			"443": {ID: "443", Name: "Owner2/Repo3", CommitCount: 3},
+		},
+	}
+	byCommits := func(ri, rj Repo) bool {
+		return ri.CommitCount > rj.CommitCount
+	}
+
+	tests := []struct {
+		name   string
+		limit  uint32
+		offset uint32
+		exp    []string
+	}{
+		{
+			name:  "ties broken by id",
+			limit: 2,
+			exp:   []string{"442", "441"},
+		},
+		{
+			name:  "limit larger than repos",
+			limit: 10,
+			exp:   []string{"442", "441", "443"},
+		},
+		{
+			name:   "offset",
+			limit:  10,
+			offset: 2,
+			exp:    []string{"443"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := []string{}
+			for _, repo := range analyzer.GetTopRepos(tt.limit, tt.offset, byCommits) {
+				got = append(got, repo.ID)
+			}
+			assert.Equal(t, tt.exp, got)
+		})
+	}
+}
+
+func TestGetRankedRepos(t *testing.T) {
+	analyzer := &Analyzer{
+		repoMap: map[string]*Repo{
+			"441": {ID: "441", Name: "Owner2/Repo1", CommitCount: 3},
+			"442": {ID: "442", Name: "Owner2/Repo2", CommitCount: 5},
+			"443": {ID: "443", Name: "Owner1/Repo3", CommitCount: 3},
+		},
+	}
+	byCommits := func(ri, rj Repo) bool {
+		return ri.CommitCount > rj.CommitCount
+	}
+
+	ranked := analyzer.GetRankedRepos(0, 0, byCommits, topk.Options{Mode: topk.Dense, TieBreaker: topk.ByName})
+	assert.Equal(t, []RankedRepo{
+		{Repo: *analyzer.repoMap["442"], Rank: 1, Tie: false},
+		{Repo: *analyzer.repoMap["443"], Rank: 2, Tie: true},
+		{Repo: *analyzer.repoMap["441"], Rank: 2, Tie: true},
+	}, ranked)
+
+	ranked = analyzer.GetRankedRepos(1, 2, byCommits, topk.Options{Mode: topk.Ordinal, TieBreaker: topk.ByID})
+	assert.Equal(t, []RankedRepo{{Repo: *analyzer.repoMap["443"], Rank: 3, Tie: true}}, ranked)
+}
+
+func TestGetRepo(t *testing.T) {
+	analyzer := &Analyzer{
+		repoMap: map[string]*Repo{
+			"441": {ID: "441", Name: "Owner1/Repo1"},
+			"442": {ID: "442", Name: "Owner2/Repo2"},
+			"443": {ID: "443", Name: "Owner2/Repo2"},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		idOrName string
+		exp      string
+		found    bool
+	}{
+		{
+			name:     "by id",
+			idOrName: "443",
+			exp:      "443",
+			found:    true,
+		},
+		{
+			name:     "by name",
+			idOrName: "Owner1/Repo1",
+			exp:      "441",
+			found:    true,
+		},
+		{
+			name:     "by duplicate name",
+			idOrName: "Owner2/Repo2",
+			exp:      "442",
+			found:    true,
+		},
+		{
+			name:     "not found",
+			idOrName: "Owner3/Repo3",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, found := analyzer.GetRepo(tt.idOrName)
+			assert.Equal(t, tt.found, found)
+			assert.Equal(t, tt.exp, got.ID)
+		})
+	}
+}
+
+func TestIndexReposConcentration(t *testing.T) {
+	events := map[string]*service.Event{
+		event1.ID: {ID: event1.ID, Type: event1.Type, Actor: &actor1, Repo: &repo2, Commits: []entities.Commit{commit1}},
+		event2.ID: {ID: event2.ID, Type: event2.Type, Actor: &actor2, Repo: &repo2, Commits: []entities.Commit{commit2, commit3}},
+		event3.ID: {ID: event3.ID, Type: event3.Type, Actor: &actor2, Repo: &repo2, Commits: []entities.Commit{commit4}},
+		event4.ID: {ID: event4.ID, Type: event4.Type, Actor: &actor1, Repo: &repo1, Commits: []entities.Commit{}},
+	}
+	eventHandler := service.EventHandler{DataStore: nil, Events: events}
+
+	got := indexRepos(eventHandler, indexContributors(eventHandler), DefaultBusFactorShare)
+
+	// actor2 has 3 of the 4 commits of repo2
+	assert.Equal(t, 1, got[repo2.ID].BusFactor)
+	assert.Equal(t, 0.25, got[repo2.ID].Gini)
+	assert.Equal(t, 0.625, got[repo2.ID].Herfindahl)
+	// repo without commits
+	assert.Equal(t, 0, got[repo1.ID].BusFactor)
+	assert.Equal(t, 0.0, got[repo1.ID].Herfindahl)
+
+	got = indexRepos(eventHandler, indexContributors(eventHandler), 1)
+	assert.Equal(t, 2, got[repo2.ID].BusFactor)
+}
+
+func TestRepoProfile(t *testing.T) {
+	events := map[string]*service.Event{
+		event1.ID: {ID: event1.ID, Type: event1.Type, Actor: &actor1, Repo: &repo2, Commits: []entities.Commit{commit1}},
+		event2.ID: {ID: event2.ID, Type: event2.Type, Actor: &actor2, Repo: &repo2, Commits: []entities.Commit{commit2, commit3}},
+		event3.ID: {ID: event3.ID, Type: event3.Type, Actor: &actor1, Repo: &repo2, Commits: []entities.Commit{}},
+		event4.ID: {ID: event4.ID, Type: event4.Type, Actor: &actor1, Repo: &repo1, Commits: []entities.Commit{}},
+	}
+	analyzer := NewAnalyzer(service.EventHandler{DataStore: nil, Events: events}, Options{})
+
+	r, ok := analyzer.GetRepo(repo2.ID)
+	assert.True(t, ok)
+	assert.Equal(t, 2, r.ContributorCount)
+	assert.Equal(t, map[string]int{"PushEvent": 1, "CreateEvent": 2}, r.EventTypeActorCount)
+
+	assert.Equal(t, []Contributor{
+		{ID: actor2.ID, Username: actor2.Username, CommitCount: 2, EventCount: 1, EventTypeCount: map[string]int{"CreateEvent": 1}},
+		{ID: actor1.ID, Username: actor1.Username, CommitCount: 1, EventCount: 2, EventTypeCount: map[string]int{"PushEvent": 1, "CreateEvent": 1}},
+	}, analyzer.GetTopContributors(r.ID, 0))
+	assert.Len(t, analyzer.GetTopContributors(r.ID, 1), 1)
+
+	byCommits := func(ri, rj Repo) bool {
+		return ri.CommitCount > rj.CommitCount
+	}
+	assert.Equal(t, Rank{Rank: 1, Percentile: 50, Total: 2}, analyzer.GetRank(r, byCommits))
+	byWatchEvents := func(ri, rj Repo) bool {
+		return ri.EventTypeCount["WatchEvent"] > rj.EventTypeCount["WatchEvent"]
+	}
+	assert.Equal(t, Rank{Rank: 2, Percentile: 0, Total: 2}, analyzer.GetRank(r, byWatchEvents))
+
+	// the repos tied with the repo are not ranked before it
+	byNothing := func(ri, rj Repo) bool {
+		return false
+	}
+	assert.Equal(t, Rank{Rank: 1, Percentile: 50, Total: 2}, analyzer.GetRank(r, byNothing))
+
+	// the repos with less than min commits are not ranked, like in the top repos
+	analyzer = NewAnalyzer(service.EventHandler{DataStore: nil, Events: events}, Options{MinCommits: 1})
+	assert.Len(t, analyzer.GetTopRepos(0, 0, byCommits), 1)
+	assert.Equal(t, Rank{Rank: 1, Percentile: 0, Total: 1}, analyzer.GetRank(r, byWatchEvents))
+}
+
+func TestGetTopContributorsPerRepo(t *testing.T) {
+	events := map[string]*service.Event{
+		event1.ID: {ID: event1.ID, Type: event1.Type, Actor: &actor1, Repo: &repo2, Commits: []entities.Commit{commit1}},
+		event2.ID: {ID: event2.ID, Type: event2.Type, Actor: &actor2, Repo: &repo2, Commits: []entities.Commit{commit2, commit3}},
+		event3.ID: {ID: event3.ID, Type: event3.Type, Actor: &actor2, Repo: &repo1, Commits: []entities.Commit{}},
+		event4.ID: {ID: event4.ID, Type: event4.Type, Actor: &actor1, Repo: &repo1, Commits: []entities.Commit{commit4}},
+	}
+	analyzer := NewAnalyzer(service.EventHandler{DataStore: nil, Events: events}, Options{})
+
+	got := analyzer.GetTopContributorsPerRepo([]string{repo1.ID, repo2.ID, "unknown"}, 1)
+	assert.Len(t, got, 3)
+	assert.Equal(t, []string{actor1.ID}, contributorIDs(got[repo1.ID]))
+	assert.Equal(t, []string{actor2.ID}, contributorIDs(got[repo2.ID]))
+	assert.Empty(t, got["unknown"])
+
+	got = analyzer.GetTopContributorsPerRepo([]string{repo2.ID}, 0)
+	assert.Len(t, got, 1)
+	assert.Equal(t, []string{actor2.ID, actor1.ID}, contributorIDs(got[repo2.ID]))
+}
+
+func contributorIDs(contributors []Contributor) []string {
+	ids := make([]string, 0, len(contributors))
+	for _, contributor := range contributors {
+		ids = append(ids, contributor.ID)
+	}
+	return ids
+}
+
+func TestSetInfluence(t *testing.T) {
+	analyzer := &Analyzer{
+		repoMap: map[string]*Repo{
+			"441": {ID: "441", Name: "Owner1/Repo1"},
+			"442": {ID: "442", Name: "Owner2/Repo2"},
+		},
+	}
+
+	analyzer.SetInfluence(map[string]float64{"442": 0.5})
+	assert.Equal(t, 0.0, analyzer.repoMap["441"].Influence)
+	assert.Equal(t, 0.5, analyzer.repoMap["442"].Influence)
+}
+
+func TestForEachContributor(t *testing.T) {
+	events := map[string]*service.Event{
+		event1.ID: {ID: event1.ID, Type: event1.Type, Actor: &actor1, Repo: &repo2, Commits: []entities.Commit{commit1}},
+		event2.ID: {ID: event2.ID, Type: event2.Type, Actor: &actor2, Repo: &repo2, Commits: []entities.Commit{commit2, commit3}},
+		event3.ID: {ID: event3.ID, Type: event3.Type, Actor: &actor1, Repo: &repo2, Commits: []entities.Commit{}},
+		event4.ID: {ID: event4.ID, Type: event4.Type, Actor: &actor1, Repo: &repo1, Commits: []entities.Commit{}},
+	}
+	analyzer := NewAnalyzer(service.EventHandler{DataStore: nil, Events: events}, Options{})
+
+	got := map[string]map[string]int{}
+	analyzer.ForEachContributor(func(repoID string, contributor Contributor) {
+		if got[repoID] == nil {
+			got[repoID] = map[string]int{}
+		}
+		got[repoID][contributor.ID] = contributor.EventCount
+	})
+	assert.Equal(t, map[string]map[string]int{
+		repo1.ID: {actor1.ID: 1},
+		repo2.ID: {actor1.ID: 2, actor2.ID: 1},
+	}, got)
+}
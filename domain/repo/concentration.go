@@ -0,0 +1,65 @@
+package repo
+
+import "sort"
+
+// DefaultBusFactorShare is the share of commits which the bus factor users should account for
+const DefaultBusFactorShare = 0.5
+
+// busFactor returns the smallest number of users accounting for given share of the commits
+func busFactor(commits []int, share float64) int {
+	total := sum(commits)
+	if total == 0 {
+		return 0
+	}
+	sorted := append([]int{}, commits...)
+	sort.Sort(sort.Reverse(sort.IntSlice(sorted)))
+
+	covered := 0
+	for i, count := range sorted {
+		covered += count
+		if float64(covered) >= share*float64(total) {
+			return i + 1
+		}
+	}
+	return len(sorted)
+}
+
+// gini returns the Gini coefficient of the per-user commits, 0 is equal & close to 1 is concentrated
+func gini(commits []int) float64 {
+	total := sum(commits)
+	if total == 0 {
+		return 0
+	}
+	sorted := append([]int{}, commits...)
+	sort.Ints(sorted)
+
+	// G = 2 * sum(i * x_i) / (n * sum(x)) - (n + 1) / n, with 1-indexed ascending x
+	weighted := 0
+	for i, count := range sorted {
+		weighted += (i + 1) * count
+	}
+	n := float64(len(sorted))
+	return 2*float64(weighted)/(n*float64(total)) - (n+1)/n
+}
+
+// herfindahl returns the Herfindahl index i.e. sum of squared per-user commit shares
+func herfindahl(commits []int) float64 {
+	total := sum(commits)
+	if total == 0 {
+		return 0
+	}
+	index := 0.0
+	for _, count := range commits {
+		share := float64(count) / float64(total)
+		index += share * share
+	}
+	return index
+}
+
+func sum(values []int) int {
+	total := 0
+	for _, value := range values {
+		total += value
+	}
+	return total
+}
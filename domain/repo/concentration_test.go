@@ -0,0 +1,67 @@
+package repo
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConcentration(t *testing.T) {
+	tests := []struct {
+		name       string
+		commits    []int
+		share      float64
+		busFactor  int
+		gini       float64
+		herfindahl float64
+	}{
+		{
+			name:       "no commits",
+			commits:    []int{},
+			share:      0.5,
+			busFactor:  0,
+			gini:       0,
+			herfindahl: 0,
+		},
+		{
+			name:       "single user",
+			commits:    []int{7},
+			share:      0.5,
+			busFactor:  1,
+			gini:       0,
+			herfindahl: 1,
+		},
+		{
+			name:       "equal users",
+			commits:    []int{2, 2, 2, 2},
+			share:      0.5,
+			busFactor:  2,
+			gini:       0,
+			herfindahl: 0.25,
+		},
+		{
+			name:       "concentrated users",
+			commits:    []int{1, 1, 8},
+			share:      0.5,
+			busFactor:  1,
+			gini:       0.4666666666666667,
+			herfindahl: 0.66,
+		},
+		{
+			name:       "concentrated users with higher share",
+			commits:    []int{1, 1, 8},
+			share:      0.9,
+			busFactor:  2,
+			gini:       0.4666666666666667,
+			herfindahl: 0.66,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.busFactor, busFactor(tt.commits, tt.share))
+			assert.True(t, math.Abs(tt.gini-gini(tt.commits)) < 1e-9)
+			assert.True(t, math.Abs(tt.herfindahl-herfindahl(tt.commits)) < 1e-9)
+		})
+	}
+}
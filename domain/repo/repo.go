@@ -1,47 +1,118 @@
 package repo
 
+import "github.com/ameykpatil/github-data-analyzer/domain/topk"
+
 // Repo encapsulates required properties related to repo
+// CommitCount counts every pushed commit whereas DistinctCommitCount counts unique shas
+// ContributorCount is the number of distinct actors & EventTypeActorCount the same per event type (e.g. stargazers)
+// BusFactor is the smallest number of users accounting for the configured share of commits,
+// Gini & Herfindahl measure how concentrated the commits are among the users
+// Influence is the PageRank based score of the repo over the activity graph, it is 0 until it is set
 type Repo struct {
+	ID                  string
+	Name                string
+	Owner               string
+	CommitCount         int
+	DistinctCommitCount int
+	EventTypeCount      map[string]int
+	ContributorCount    int
+	EventTypeActorCount map[string]int
+	BusFactor           int
+	Gini                float64
+	Herfindahl          float64
+	Influence           float64
+}
+
+// RankedRepo is a repo with its rank in a top list, Tie is true if another repo has the same sort key
+type RankedRepo struct {
+	Repo
+	Rank int
+	Tie  bool
+}
+
+// repoRanking ranks the repos using the given sort function (topk Interface)
+type repoRanking struct {
+	repos      []Repo
+	less       func(i, j Repo) bool
+	tieBreaker topk.TieBreaker
+}
+
+// Len is the number of repos to rank
+func (r repoRanking) Len() int {
+	return len(r.repos)
+}
+
+// Less reports whether the repo i ranks before the repo j
+func (r repoRanking) Less(i, j int) bool {
+	return r.less(r.repos[i], r.repos[j])
+}
+
+// ID is used to break the ties between repos
+func (r repoRanking) ID(i int) string {
+	return topk.TieKey(r.tieBreaker, r.repos[i].ID, r.repos[i].Name)
+}
+
+// Contributor encapsulates the activity of a user on a repo
+type Contributor struct {
 	ID             string
-	Name           string
+	Username       string
 	CommitCount    int
+	EventCount     int
 	EventTypeCount map[string]int
 }
 
-// base heap structure for Repo
-type baseRepoHeap []Repo
-
-// Swap is required to swap the elements of the heap (Sort interface)
-func (r baseRepoHeap) Swap(i, j int) {
-	r[i], r[j] = r[j], r[i]
+// Rank encapsulates the position of a repo among all the repos under a sort function
+// Rank is 1 + number of repos ranked strictly before, Percentile is the share of the other repos
+// not ranked before i.e. ranked after or tied, so it matches the rank
+type Rank struct {
+	Rank       int
+	Percentile float64
+	Total      int
 }
 
-// Len is required to know the length (Sort interface)
-func (r baseRepoHeap) Len() int {
+// contributorRanking ranks the contributors by commits & then events (topk Interface)
+type contributorRanking []Contributor
+
+// Len is the number of contributors to rank
+func (r contributorRanking) Len() int {
 	return len(r)
 }
 
-// Push adds an element to heap (Heap interface)
-func (r *baseRepoHeap) Push(x interface{}) {
-	*r = append(*r, x.(Repo))
+// Less reports whether the contributor i ranks before the contributor j
+func (r contributorRanking) Less(i, j int) bool {
+	if r[i].CommitCount == r[j].CommitCount {
+		return r[i].EventCount > r[j].EventCount
+	}
+	return r[i].CommitCount > r[j].CommitCount
+}
+
+// ID is used to break the ties between contributors
+func (r contributorRanking) ID(i int) string {
+	return r[i].ID
+}
+
+// groupedContributorRanking ranks the contributors of every repo by commits & then events (topk GroupedInterface)
+type groupedContributorRanking struct {
+	contributors []Contributor
+	repoIDs      []string
+}
+
+// Len is the number of contributors to rank
+func (r groupedContributorRanking) Len() int {
+	return len(r.contributors)
 }
 
-// Pop take out the top element from the heap (Heap interface)
-func (r *baseRepoHeap) Pop() interface{} {
-	old := *r
-	n := len(old)
-	x := old[n-1]
-	*r = old[0 : n-1]
-	return x
+// Less reports whether the contributor i ranks before the contributor j
+func (r groupedContributorRanking) Less(i, j int) bool {
+	return contributorRanking(r.contributors).Less(i, j)
 }
 
-// concrete heap structure for repo
-type repoHeap struct {
-	baseRepoHeap
-	less func(i, j Repo) bool
+// ID is used to break the ties between contributors
+func (r groupedContributorRanking) ID(i int) string {
+	return r.contributors[i].ID
 }
 
-// Less defines the way to determine lesser element (Sort interface)
-func (r repoHeap) Less(i, j int) bool {
-	return r.less(r.baseRepoHeap[i], r.baseRepoHeap[j])
+// Group is the repo of the contributor i
+func (r groupedContributorRanking) Group(i int) string {
+	return r.repoIDs[i]
 }
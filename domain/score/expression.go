@@ -0,0 +1,244 @@
+// Package score parses & evaluates weighted score expressions over the metrics of users & repos
+// e.g. 3*PullRequestEvent + Commits + 0.5*IssueCommentEvent
+//
+// The language only has numbers, metric names, + - * / & parentheses, so evaluating an expression is safe.
+// Metric names are letters, digits, _, : & . (e.g. External:Commits or Repos.PushEvent) & division by zero evaluates to 0.
+package score
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"unicode"
+)
+
+// Expression is a parsed score expression
+type Expression struct {
+	text      string
+	root      node
+	variables []string
+}
+
+// Parse parses the text into an expression
+func Parse(text string) (*Expression, error) {
+	tokens, err := tokenize(text)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+	root, err := p.parseSum()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("unexpected %q in expression %s", p.tokens[p.pos].text, text)
+	}
+
+	variables := []string{}
+	seen := map[string]bool{}
+	for _, t := range tokens {
+		if t.kind == identToken && !seen[t.text] {
+			seen[t.text] = true
+			variables = append(variables, t.text)
+		}
+	}
+	return &Expression{text: text, root: root, variables: variables}, nil
+}
+
+// Variables returns the metric names used in the expression in order of appearance
+func (e *Expression) Variables() []string {
+	return e.variables
+}
+
+// Evaluate computes the value of the expression, value returns the value of a metric
+func (e *Expression) Evaluate(value func(name string) float64) float64 {
+	return e.root.eval(value)
+}
+
+// String returns the text of the expression
+func (e *Expression) String() string {
+	return e.text
+}
+
+// node is a node of the syntax tree of an expression
+type node interface {
+	eval(value func(name string) float64) float64
+}
+
+type number float64
+
+func (n number) eval(value func(name string) float64) float64 {
+	return float64(n)
+}
+
+type variable string
+
+func (v variable) eval(value func(name string) float64) float64 {
+	return value(string(v))
+}
+
+type negation struct {
+	operand node
+}
+
+func (n negation) eval(value func(name string) float64) float64 {
+	return -n.operand.eval(value)
+}
+
+type binary struct {
+	op          byte
+	left, right node
+}
+
+func (b binary) eval(value func(name string) float64) float64 {
+	left, right := b.left.eval(value), b.right.eval(value)
+	switch b.op {
+	case '+':
+		return left + right
+	case '-':
+		return left - right
+	case '*':
+		return left * right
+	default:
+		if right == 0 {
+			return 0
+		}
+		return left / right
+	}
+}
+
+// token kinds
+const (
+	numberToken = iota
+	identToken
+	opToken
+)
+
+type token struct {
+	kind int
+	text string
+}
+
+// tokenize splits the text into numbers, metric names & operators, spaces are skipped
+func tokenize(text string) ([]token, error) {
+	tokens := []token{}
+	runes := []rune(text)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case unicode.IsDigit(r) || r == '.':
+			j := i
+			for j < len(runes) && (unicode.IsDigit(runes[j]) || runes[j] == '.') {
+				j++
+			}
+			tokens = append(tokens, token{kind: numberToken, text: string(runes[i:j])})
+			i = j
+		case unicode.IsLetter(r) || r == '_':
+			j := i
+			for j < len(runes) && (unicode.IsLetter(runes[j]) || unicode.IsDigit(runes[j]) || runes[j] == '_' || runes[j] == ':' || runes[j] == '.') {
+				j++
+			}
+			tokens = append(tokens, token{kind: identToken, text: string(runes[i:j])})
+			i = j
+		case r == '+' || r == '-' || r == '*' || r == '/' || r == '(' || r == ')':
+			tokens = append(tokens, token{kind: opToken, text: string(r)})
+			i++
+		default:
+			return nil, fmt.Errorf("invalid character %q in expression %s", r, text)
+		}
+	}
+	if len(tokens) == 0 {
+		return nil, errors.New("empty expression")
+	}
+	return tokens, nil
+}
+
+// parser is a recursive descent parser of the grammar
+//
+//	sum     = product { ("+" | "-") product }
+//	product = unary { ("*" | "/") unary }
+//	unary   = ("+" | "-") unary | number | metric | "(" sum ")"
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+// peekOp returns the operator at the current position or 0 if there is none
+func (p *parser) peekOp() byte {
+	if p.pos < len(p.tokens) && p.tokens[p.pos].kind == opToken {
+		return p.tokens[p.pos].text[0]
+	}
+	return 0
+}
+
+func (p *parser) parseSum() (node, error) {
+	left, err := p.parseProduct()
+	if err != nil {
+		return nil, err
+	}
+	for op := p.peekOp(); op == '+' || op == '-'; op = p.peekOp() {
+		p.pos++
+		right, err := p.parseProduct()
+		if err != nil {
+			return nil, err
+		}
+		left = binary{op: op, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseProduct() (node, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for op := p.peekOp(); op == '*' || op == '/'; op = p.peekOp() {
+		p.pos++
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = binary{op: op, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseUnary() (node, error) {
+	if p.pos >= len(p.tokens) {
+		return nil, errors.New("unexpected end of expression")
+	}
+	t := p.tokens[p.pos]
+	p.pos++
+	switch {
+	case t.kind == numberToken:
+		value, err := strconv.ParseFloat(t.text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %s", t.text)
+		}
+		return number(value), nil
+	case t.kind == identToken:
+		return variable(t.text), nil
+	case t.text == "+":
+		return p.parseUnary()
+	case t.text == "-":
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return negation{operand: operand}, nil
+	case t.text == "(":
+		inner, err := p.parseSum()
+		if err != nil {
+			return nil, err
+		}
+		if p.peekOp() != ')' {
+			return nil, errors.New("missing ) in expression")
+		}
+		p.pos++
+		return inner, nil
+	default:
+		return nil, fmt.Errorf("unexpected %q in expression", t.text)
+	}
+}
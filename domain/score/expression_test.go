@@ -0,0 +1,88 @@
+package score
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvaluate(t *testing.T) {
+	metrics := map[string]float64{
+		"PullRequestEvent":  2,
+		"Commits":           10,
+		"IssueCommentEvent": 3,
+		"External:Commits":  4,
+		"Repos.PushEvent":   5,
+	}
+	value := func(name string) float64 {
+		return metrics[name]
+	}
+
+	tests := []struct {
+		name       string
+		expression string
+		exp        float64
+		variables  []string
+	}{
+		{
+			name:       "weighted sum",
+			expression: "3*PullRequestEvent + Commits + 0.5*IssueCommentEvent",
+			exp:        17.5,
+			variables:  []string{"PullRequestEvent", "Commits", "IssueCommentEvent"},
+		},
+		{
+			name:       "precedence & parentheses",
+			expression: "(Commits - PullRequestEvent) / 4 * 2",
+			exp:        4,
+			variables:  []string{"Commits", "PullRequestEvent"},
+		},
+		{
+			name:       "unary minus",
+			expression: "-Commits + -(-2)",
+			exp:        -8,
+			variables:  []string{"Commits"},
+		},
+		{
+			name:       "scoped metric",
+			expression: "Commits - External:Commits",
+			exp:        6,
+			variables:  []string{"Commits", "External:Commits"},
+		},
+		{
+			name:       "distinct count metric",
+			expression: "Commits/Repos.PushEvent",
+			exp:        2,
+			variables:  []string{"Commits", "Repos.PushEvent"},
+		},
+		{
+			name:       "division by zero",
+			expression: "Commits / (PullRequestEvent - 2)",
+			exp:        0,
+			variables:  []string{"Commits", "PullRequestEvent"},
+		},
+		{
+			name:       "repeated metric",
+			expression: "Commits*Commits",
+			exp:        100,
+			variables:  []string{"Commits"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expression, err := Parse(tt.expression)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.exp, expression.Evaluate(value))
+			assert.Equal(t, tt.variables, expression.Variables())
+			assert.Equal(t, tt.expression, expression.String())
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, text := range []string{"", "  ", "3*", "(Commits", "Commits)", "Commits Commits", "Commits % 2", "1.2.3", "os.Exit(1)"} {
+		t.Run(text, func(t *testing.T) {
+			_, err := Parse(text)
+			assert.Error(t, err)
+		})
+	}
+}
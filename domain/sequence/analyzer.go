@@ -0,0 +1,152 @@
+// Package sequence mines the order of the events of the users, the event ids increase over time
+package sequence
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/ameykpatil/github-data-analyzer/domain/topk"
+	"github.com/ameykpatil/github-data-analyzer/service"
+)
+
+// Options select the sequences to analyze
+// ByRepo splits the events of a user into a sequence per repo, Repo (id or name) keeps only the events of the repo
+// & consecutive events of the same type are collapsed into one unless Repeats is true
+type Options struct {
+	ByRepo  bool
+	Repo    string
+	Repeats bool
+}
+
+// Analyzer encapsulates functionality of analyzing the sequences of events
+type Analyzer struct {
+	eventHandler service.EventHandler
+}
+
+// NewAnalyzer creates a new instance of sequence Analyzer
+func NewAnalyzer(eventHandler service.EventHandler) *Analyzer {
+	return &Analyzer{
+		eventHandler: eventHandler,
+	}
+}
+
+// GetSequences returns the event types of every user (or user & repo) ordered by event id
+func (sa *Analyzer) GetSequences(options Options) [][]string {
+	type sequenceKey struct {
+		userID string
+		repoID string
+	}
+	eventMap := map[sequenceKey][]*service.Event{}
+	for _, event := range sa.eventHandler.Events {
+		if event.Actor == nil {
+			continue
+		}
+		key := sequenceKey{userID: event.Actor.ID}
+		if options.ByRepo || options.Repo != "" {
+			if event.Repo == nil {
+				continue
+			}
+			if options.Repo != "" && event.Repo.ID != options.Repo && event.Repo.Name != options.Repo {
+				continue
+			}
+			key.repoID = event.Repo.ID
+		}
+		eventMap[key] = append(eventMap[key], event)
+	}
+
+	keys := make([]sequenceKey, 0, len(eventMap))
+	for key := range eventMap {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].userID == keys[j].userID {
+			return keys[i].repoID < keys[j].repoID
+		}
+		return keys[i].userID < keys[j].userID
+	})
+
+	sequences := make([][]string, 0, len(keys))
+	for _, key := range keys {
+		events := eventMap[key]
+		sort.Slice(events, func(i, j int) bool {
+			return lessID(events[i].ID, events[j].ID)
+		})
+		sequence := make([]string, 0, len(events))
+		for _, event := range events {
+			if !options.Repeats && len(sequence) > 0 && sequence[len(sequence)-1] == event.Type {
+				continue
+			}
+			sequence = append(sequence, event.Type)
+		}
+		sequences = append(sequences, sequence)
+	}
+	return sequences
+}
+
+// GetTransitions returns the transitions between the event types of the sequences (a Markov chain) ranked by count
+// limit 0 returns all the transitions
+func GetTransitions(sequences [][]string, limit uint32) []Transition {
+	counts := map[[2]string]int{}
+	outgoing := map[string]int{}
+	for _, sequence := range sequences {
+		for i := 1; i < len(sequence); i++ {
+			counts[[2]string{sequence[i-1], sequence[i]}]++
+			outgoing[sequence[i-1]]++
+		}
+	}
+
+	all := make([]Transition, 0, len(counts))
+	for pair, count := range counts {
+		all = append(all, Transition{
+			From:        pair[0],
+			To:          pair[1],
+			Count:       count,
+			Probability: float64(count) / float64(outgoing[pair[0]]),
+		})
+	}
+	transitions := make([]Transition, 0, len(all))
+	for _, i := range topk.Select(transitionRanking(all), int(limit), 0) {
+		transitions = append(transitions, all[i])
+	}
+	return transitions
+}
+
+// GetPatterns returns the most common runs of the given number of steps in the sequences ranked by count
+// limit 0 returns all the patterns
+func GetPatterns(sequences [][]string, steps int, limit uint32) []Pattern {
+	patternMap := map[string]*Pattern{}
+	for _, sequence := range sequences {
+		seen := map[string]bool{}
+		for i := 0; i+steps <= len(sequence); i++ {
+			key := strings.Join(sequence[i:i+steps], "\x00")
+			pattern, ok := patternMap[key]
+			if !ok {
+				pattern = &Pattern{Steps: sequence[i : i+steps]}
+				patternMap[key] = pattern
+			}
+			pattern.Count++
+			if !seen[key] {
+				seen[key] = true
+				pattern.Sequences++
+			}
+		}
+	}
+
+	all := make([]Pattern, 0, len(patternMap))
+	for _, pattern := range patternMap {
+		all = append(all, *pattern)
+	}
+	patterns := make([]Pattern, 0, len(all))
+	for _, i := range topk.Select(patternRanking(all), int(limit), 0) {
+		patterns = append(patterns, all[i])
+	}
+	return patterns
+}
+
+// lessID reports whether the event id a is less than b, the ids are compared as numbers
+func lessID(a, b string) bool {
+	if len(a) != len(b) {
+		return len(a) < len(b)
+	}
+	return a < b
+}
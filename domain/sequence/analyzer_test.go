@@ -0,0 +1,103 @@
+package sequence
+
+import (
+	"testing"
+
+	"github.com/ameykpatil/github-data-analyzer/db/entities"
+	"github.com/ameykpatil/github-data-analyzer/service"
+	"github.com/stretchr/testify/assert"
+)
+
+// newAnalyzer creates a sequence analyzer of the given events, the event types are indexed by event id
+func newAnalyzer() *Analyzer {
+	actor1 := &entities.Actor{ID: "111", Username: "Actor1"}
+	actor2 := &entities.Actor{ID: "222", Username: "Actor2"}
+	repo1 := &entities.Repo{ID: "1", Name: "Owner/Repo1"}
+	repo2 := &entities.Repo{ID: "2", Name: "Owner/Repo2"}
+	events := map[string]*service.Event{
+		"9":  {ID: "9", Type: "ForkEvent", Actor: actor1, Repo: repo1},
+		"10": {ID: "10", Type: "PushEvent", Actor: actor1, Repo: repo2},
+		"11": {ID: "11", Type: "PushEvent", Actor: actor1, Repo: repo2},
+		"12": {ID: "12", Type: "PullRequestEvent", Actor: actor1, Repo: repo1},
+		"20": {ID: "20", Type: "ForkEvent", Actor: actor2, Repo: repo1},
+		"21": {ID: "21", Type: "PushEvent", Actor: actor2, Repo: repo2},
+		"22": {ID: "22", Type: "PullRequestEvent", Actor: actor2, Repo: repo1},
+		"23": {ID: "23", Type: "WatchEvent", Actor: actor2, Repo: repo1},
+	}
+	return NewAnalyzer(service.EventHandler{DataStore: nil, Events: events})
+}
+
+func TestGetSequences(t *testing.T) {
+	analyzer := newAnalyzer()
+
+	// the events are ordered by numeric id & the repeats are collapsed
+	assert.Equal(t, [][]string{
+		{"ForkEvent", "PushEvent", "PullRequestEvent"},
+		{"ForkEvent", "PushEvent", "PullRequestEvent", "WatchEvent"},
+	}, analyzer.GetSequences(Options{}))
+
+	assert.Equal(t, []string{"ForkEvent", "PushEvent", "PushEvent", "PullRequestEvent"}, analyzer.GetSequences(Options{Repeats: true})[0])
+
+	// by repo there is a sequence per user & repo
+	assert.Equal(t, [][]string{
+		{"ForkEvent", "PullRequestEvent"},
+		{"PushEvent"},
+		{"ForkEvent", "PullRequestEvent", "WatchEvent"},
+		{"PushEvent"},
+	}, analyzer.GetSequences(Options{ByRepo: true}))
+
+	// the repo is matched by id or name
+	assert.Equal(t, [][]string{{"PushEvent"}, {"PushEvent"}}, analyzer.GetSequences(Options{Repo: "Owner/Repo2"}))
+	assert.Len(t, analyzer.GetSequences(Options{Repo: "1"}), 2)
+	assert.Empty(t, analyzer.GetSequences(Options{Repo: "Owner/Repo3"}))
+}
+
+func TestGetTransitions(t *testing.T) {
+	sequences := newAnalyzer().GetSequences(Options{})
+
+	transitions := GetTransitions(sequences, 0)
+	assert.Equal(t, []Transition{
+		{From: "ForkEvent", To: "PushEvent", Count: 2, Probability: 1},
+		{From: "PushEvent", To: "PullRequestEvent", Count: 2, Probability: 1},
+		{From: "PullRequestEvent", To: "WatchEvent", Count: 1, Probability: 1},
+	}, transitions)
+
+	// the probabilities of the transitions from an event type sum up to 1
+	transitions = GetTransitions(newAnalyzer().GetSequences(Options{Repeats: true}), 0)
+	probabilities := map[string]float64{}
+	for _, transition := range transitions {
+		probabilities[transition.From] += transition.Probability
+	}
+	for from, probability := range probabilities {
+		assert.InDelta(t, 1, probability, 1e-9, from)
+	}
+	assert.Equal(t, Transition{From: "PushEvent", To: "PullRequestEvent", Count: 2, Probability: 2.0 / 3}, transitions[1])
+
+	assert.Len(t, GetTransitions(sequences, 1), 1)
+	assert.Empty(t, GetTransitions(nil, 0))
+}
+
+func TestGetPatterns(t *testing.T) {
+	sequences := newAnalyzer().GetSequences(Options{})
+
+	patterns := GetPatterns(sequences, 3, 0)
+	assert.Len(t, patterns, 2)
+	assert.Equal(t, "ForkEvent -> PushEvent -> PullRequestEvent", patterns[0].String())
+	assert.Equal(t, 2, patterns[0].Count)
+	assert.Equal(t, 2, patterns[0].Sequences)
+	assert.Equal(t, []string{"PushEvent", "PullRequestEvent", "WatchEvent"}, patterns[1].Steps)
+
+	// the occurrences are counted once per position & the sequences once per pattern
+	patterns = GetPatterns([][]string{{"PushEvent", "PushEvent", "PushEvent"}}, 2, 0)
+	assert.Equal(t, []Pattern{{Steps: []string{"PushEvent", "PushEvent"}, Count: 2, Sequences: 1}}, patterns)
+
+	assert.Len(t, GetPatterns(sequences, 2, 1), 1)
+	assert.Empty(t, GetPatterns(sequences, 5, 0))
+}
+
+func TestLessID(t *testing.T) {
+	assert.True(t, lessID("9", "10"))
+	assert.True(t, lessID("11185376329", "11185376330"))
+	assert.False(t, lessID("100", "99"))
+	assert.False(t, lessID("10", "10"))
+}
@@ -0,0 +1,64 @@
+package sequence
+
+import "strings"
+
+// Transition is a step from an event type to the next one of the same user
+// Probability is the share of the steps from the From event type which go to the To event type
+type Transition struct {
+	From        string
+	To          string
+	Count       int
+	Probability float64
+}
+
+// Pattern is a run of consecutive event types of the same user
+// Count is the number of occurrences & Sequences the number of sequences it occurs in
+type Pattern struct {
+	Steps     []string
+	Count     int
+	Sequences int
+}
+
+// String returns the steps of the pattern joined by arrows
+func (p Pattern) String() string {
+	return strings.Join(p.Steps, " -> ")
+}
+
+// transitionRanking ranks the transitions by count (topk Interface)
+type transitionRanking []Transition
+
+// Len is the number of transitions to rank
+func (r transitionRanking) Len() int {
+	return len(r)
+}
+
+// Less reports whether the transition i ranks before the transition j
+func (r transitionRanking) Less(i, j int) bool {
+	return r[i].Count > r[j].Count
+}
+
+// ID is used to break the ties between transitions
+func (r transitionRanking) ID(i int) string {
+	return r[i].From + " -> " + r[i].To
+}
+
+// patternRanking ranks the patterns by count & then by the number of sequences (topk Interface)
+type patternRanking []Pattern
+
+// Len is the number of patterns to rank
+func (r patternRanking) Len() int {
+	return len(r)
+}
+
+// Less reports whether the pattern i ranks before the pattern j
+func (r patternRanking) Less(i, j int) bool {
+	if r[i].Count == r[j].Count {
+		return r[i].Sequences > r[j].Sequences
+	}
+	return r[i].Count > r[j].Count
+}
+
+// ID is used to break the ties between patterns
+func (r patternRanking) ID(i int) string {
+	return r[i].String()
+}
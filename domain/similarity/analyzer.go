@@ -0,0 +1,100 @@
+package similarity
+
+import (
+	"errors"
+	"math"
+
+	"github.com/ameykpatil/github-data-analyzer/domain/repo"
+	"github.com/ameykpatil/github-data-analyzer/domain/topk"
+)
+
+// Analyzer encapsulates functionality of finding similar repos
+type Analyzer struct {
+	repoAnalyzer *repo.Analyzer
+	// repoVectors is the activity of each user on the repo (by repo id & user id)
+	repoVectors map[string]map[string]float64
+	// userIndex is the inverted index of repos each user was active on (by user id)
+	userIndex map[string][]string
+	// repoNorms is the euclidean norm of the activity vector of the repo (by repo id)
+	repoNorms map[string]float64
+}
+
+// NewAnalyzer creates a new instance of similarity Analyzer on top of the contributors of the repo analyzer
+func NewAnalyzer(repoAnalyzer *repo.Analyzer) *Analyzer {
+	repoVectors, userIndex, repoNorms := indexActivity(repoAnalyzer)
+	return &Analyzer{
+		repoAnalyzer: repoAnalyzer,
+		repoVectors:  repoVectors,
+		userIndex:    userIndex,
+		repoNorms:    repoNorms,
+	}
+}
+
+// indexActivity creates the activity vectors of repos, the inverted index of users & the norms of the vectors
+// the activity of a user on a repo is its number of events on the repo, taken from the contributors of the repo
+func indexActivity(repoAnalyzer *repo.Analyzer) (map[string]map[string]float64, map[string][]string, map[string]float64) {
+	repoVectors := make(map[string]map[string]float64)
+	userIndex := make(map[string][]string)
+	repoAnalyzer.ForEachContributor(func(repoID string, contributor repo.Contributor) {
+		vector, ok := repoVectors[repoID]
+		if !ok {
+			vector = map[string]float64{}
+			repoVectors[repoID] = vector
+		}
+		vector[contributor.ID] = float64(contributor.EventCount)
+		userIndex[contributor.ID] = append(userIndex[contributor.ID], repoID)
+	})
+
+	repoNorms := make(map[string]float64, len(repoVectors))
+	for id, vector := range repoVectors {
+		sum := 0.0
+		for _, activity := range vector {
+			sum += activity * activity
+		}
+		repoNorms[id] = math.Sqrt(sum)
+	}
+
+	return repoVectors, userIndex, repoNorms
+}
+
+// GetSimilarRepos returns repos sharing contributors with the repo of given id or name
+// based on provided limit, offset & sort function
+func (sa *Analyzer) GetSimilarRepos(idOrName string, limit, offset uint32, fn func(si, sj Similarity) bool) (repo.Repo, []Similarity, error) {
+	target, ok := sa.repoAnalyzer.GetRepo(idOrName)
+	if !ok {
+		return repo.Repo{}, nil, errors.New("repo not found " + idOrName)
+	}
+	vector := sa.repoVectors[target.ID]
+
+	// only the repos sharing at least one contributor are visited using the inverted index
+	dots := map[string]float64{}
+	shared := map[string]int{}
+	for userID, activity := range vector {
+		for _, repoID := range sa.userIndex[userID] {
+			if repoID == target.ID {
+				continue
+			}
+			dots[repoID] += activity * sa.repoVectors[repoID][userID]
+			shared[repoID]++
+		}
+	}
+
+	all := make([]Similarity, 0, len(shared))
+	for repoID, count := range shared {
+		r, _ := sa.repoAnalyzer.GetRepo(repoID)
+		all = append(all, Similarity{
+			Repo:               r,
+			SharedContributors: count,
+			Jaccard:            float64(count) / float64(len(vector)+len(sa.repoVectors[repoID])-count),
+			Cosine:             dots[repoID] / (sa.repoNorms[target.ID] * sa.repoNorms[repoID]),
+		})
+	}
+
+	indices := topk.Select(similarityRanking{similarities: all, less: fn}, int(limit), int(offset))
+	similarities := make([]Similarity, 0, len(indices))
+	for _, i := range indices {
+		similarities = append(similarities, all[i])
+	}
+
+	return target, similarities, nil
+}
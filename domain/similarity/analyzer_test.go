@@ -0,0 +1,69 @@
+package similarity
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ameykpatil/github-data-analyzer/db/entities"
+	"github.com/ameykpatil/github-data-analyzer/domain/repo"
+	"github.com/ameykpatil/github-data-analyzer/service"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	actor1 = entities.Actor{ID: "111", Username: "Actor1"}
+	actor2 = entities.Actor{ID: "112", Username: "Actor2"}
+	actor3 = entities.Actor{ID: "113", Username: "Actor3"}
+	repo1  = entities.Repo{ID: "441", Name: "Owner1/Repo1"}
+	repo2  = entities.Repo{ID: "442", Name: "Owner2/Repo2"}
+	repo3  = entities.Repo{ID: "443", Name: "Owner3/Repo3"}
+	repo4  = entities.Repo{ID: "444", Name: "Owner4/Repo4"}
+	events = map[string]*service.Event{
+		// repo1 has actor1 & actor2
+		"331": {ID: "331", Type: "PushEvent", Actor: &actor1, Repo: &repo1},
+		"332": {ID: "332", Type: "PushEvent", Actor: &actor2, Repo: &repo1},
+		// repo2 has actor1 & actor2, same as repo1
+		"333": {ID: "333", Type: "PushEvent", Actor: &actor1, Repo: &repo2},
+		"334": {ID: "334", Type: "PushEvent", Actor: &actor2, Repo: &repo2},
+		"335": {ID: "335", Type: "PushEvent", Actor: &actor2, Repo: &repo2},
+		// repo3 has actor1 & actor3
+		"336": {ID: "336", Type: "WatchEvent", Actor: &actor1, Repo: &repo3},
+		"337": {ID: "337", Type: "WatchEvent", Actor: &actor3, Repo: &repo3},
+		// repo4 shares no contributor
+		"338": {ID: "338", Type: "WatchEvent", Actor: &actor3, Repo: &repo4},
+	}
+	byJaccard = func(si, sj Similarity) bool {
+		return si.Jaccard > sj.Jaccard
+	}
+)
+
+func TestGetSimilarRepos(t *testing.T) {
+	eventHandler := service.EventHandler{DataStore: nil, Events: events}
+	analyzer := NewAnalyzer(repo.NewAnalyzer(eventHandler, repo.Options{}))
+
+	target, got, err := analyzer.GetSimilarRepos("Owner1/Repo1", 0, 0, byJaccard)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "441", target.ID)
+	assert.Len(t, got, 2)
+
+	assert.Equal(t, "442", got[0].Repo.ID)
+	assert.Equal(t, 2, got[0].SharedContributors)
+	assert.Equal(t, 1.0, got[0].Jaccard)
+	// (1*1 + 1*2) / (sqrt(2) * sqrt(5))
+	assert.True(t, math.Abs(got[0].Cosine-3/math.Sqrt(10)) < 1e-9)
+
+	assert.Equal(t, "443", got[1].Repo.ID)
+	assert.Equal(t, 1, got[1].SharedContributors)
+	assert.Equal(t, 1.0/3, got[1].Jaccard)
+	assert.True(t, math.Abs(got[1].Cosine-0.5) < 1e-9)
+}
+
+func TestGetSimilarReposNotFound(t *testing.T) {
+	eventHandler := service.EventHandler{DataStore: nil, Events: events}
+	analyzer := NewAnalyzer(repo.NewAnalyzer(eventHandler, repo.Options{}))
+
+	_, _, err := analyzer.GetSimilarRepos("Owner5/Repo5", 0, 0, byJaccard)
+
+	assert.EqualError(t, err, "repo not found Owner5/Repo5")
+}
@@ -0,0 +1,33 @@
+package similarity
+
+import "github.com/ameykpatil/github-data-analyzer/domain/repo"
+
+// Similarity encapsulates how similar a repo is to the given repo based on their contributors
+// Jaccard is computed over the contributor sets & Cosine over the per-user activity (number of events) vectors
+type Similarity struct {
+	Repo               repo.Repo
+	SharedContributors int
+	Jaccard            float64
+	Cosine             float64
+}
+
+// similarityRanking ranks the similarities using the given sort function (topk Interface)
+type similarityRanking struct {
+	similarities []Similarity
+	less         func(i, j Similarity) bool
+}
+
+// Len is the number of similarities to rank
+func (r similarityRanking) Len() int {
+	return len(r.similarities)
+}
+
+// Less reports whether the similarity i ranks before the similarity j
+func (r similarityRanking) Less(i, j int) bool {
+	return r.less(r.similarities[i], r.similarities[j])
+}
+
+// ID is used to break the ties between similarities
+func (r similarityRanking) ID(i int) string {
+	return r.similarities[i].Repo.ID
+}
@@ -0,0 +1,101 @@
+package sketch
+
+import (
+	"errors"
+	"math"
+)
+
+// CountMin estimates the frequency of keys with Depth rows of Width counters
+// an estimate is never lower than the true count & exceeds it by at most ErrorBound with probability Confidence
+type CountMin struct {
+	Width  uint32     `json:"width"`
+	Depth  uint32     `json:"depth"`
+	Total  uint64     `json:"total"`
+	Counts [][]uint64 `json:"counts"`
+}
+
+// NewCountMin creates an empty Count-Min sketch with the given width & depth
+func NewCountMin(width, depth uint32) (*CountMin, error) {
+	if width == 0 || depth == 0 {
+		return nil, errors.New("width & depth should be greater than 0")
+	}
+	counts := make([][]uint64, depth)
+	for i := range counts {
+		counts[i] = make([]uint64, width)
+	}
+	return &CountMin{
+		Width:  width,
+		Depth:  depth,
+		Counts: counts,
+	}, nil
+}
+
+// Validate checks the width & depth of the counters, e.g. of a decoded Count-Min sketch
+func (cm *CountMin) Validate() error {
+	if cm.Width == 0 || cm.Depth == 0 {
+		return errors.New("width & depth should be greater than 0")
+	}
+	if len(cm.Counts) != int(cm.Depth) {
+		return errors.New("number of rows does not match the depth")
+	}
+	for _, row := range cm.Counts {
+		if len(row) != int(cm.Width) {
+			return errors.New("number of counters does not match the width")
+		}
+	}
+	return nil
+}
+
+// Add adds count to the frequency of the key
+func (c *CountMin) Add(key string, count uint64) {
+	c.Total += count
+	for i, index := range c.indices(key) {
+		c.Counts[i][index] += count
+	}
+}
+
+// Estimate returns the estimated frequency of the key
+func (c *CountMin) Estimate(key string) uint64 {
+	estimate := uint64(math.MaxUint64)
+	for i, index := range c.indices(key) {
+		if c.Counts[i][index] < estimate {
+			estimate = c.Counts[i][index]
+		}
+	}
+	return estimate
+}
+
+// ErrorBound returns the maximum overestimation i.e. e / Width of the total count
+func (c *CountMin) ErrorBound() uint64 {
+	return uint64(math.Ceil(math.E / float64(c.Width) * float64(c.Total)))
+}
+
+// Confidence returns the probability of an estimate to be within the error bound i.e. 1 - e^-Depth
+func (c *CountMin) Confidence() float64 {
+	return 1 - math.Exp(-float64(c.Depth))
+}
+
+// Merge merges the other Count-Min sketch into this one, both should have the same width & depth
+func (c *CountMin) Merge(other *CountMin) error {
+	if c.Width != other.Width || c.Depth != other.Depth {
+		return errors.New("cannot merge Count-Min sketches with different width or depth")
+	}
+	c.Total += other.Total
+	for i := range c.Counts {
+		for j := range c.Counts[i] {
+			c.Counts[i][j] += other.Counts[i][j]
+		}
+	}
+	return nil
+}
+
+// indices returns the counter index of the key in each row using double hashing
+func (c *CountMin) indices(key string) []uint32 {
+	x := hash64(key)
+	h1, h2 := uint32(x), uint32(x>>32)|1
+	indices := make([]uint32, c.Depth)
+	for i := range indices {
+		indices[i] = (h1 + uint32(i)*h2) % c.Width
+	}
+	return indices
+}
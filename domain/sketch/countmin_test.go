@@ -0,0 +1,46 @@
+package sketch
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCountMin(t *testing.T) {
+	cm, err := NewCountMin(256, 5)
+	assert.NoError(t, err)
+	counts := map[string]uint64{}
+	for i := 0; i < 2000; i++ {
+		key := strconv.Itoa(i % 500)
+		cm.Add(key, uint64(i%7))
+		counts[key] += uint64(i % 7)
+	}
+
+	violations := 0
+	for key, count := range counts {
+		estimate := cm.Estimate(key)
+		assert.GreaterOrEqual(t, estimate, count)
+		if estimate > count+cm.ErrorBound() {
+			violations++
+		}
+	}
+	// estimates exceed the bound with probability at most 1 - confidence
+	assert.LessOrEqual(t, float64(violations), 2*(1-cm.Confidence())*float64(len(counts))+1)
+}
+
+func TestCountMinMerge(t *testing.T) {
+	a, _ := NewCountMin(64, 3)
+	b, _ := NewCountMin(64, 3)
+	a.Add("x", 3)
+	a.Add("y", 1)
+	b.Add("x", 2)
+	assert.NoError(t, a.Merge(b))
+	assert.Equal(t, uint64(6), a.Total)
+	assert.GreaterOrEqual(t, a.Estimate("x"), uint64(5))
+
+	other, _ := NewCountMin(32, 3)
+	assert.Error(t, a.Merge(other))
+	_, err := NewCountMin(0, 3)
+	assert.Error(t, err)
+}
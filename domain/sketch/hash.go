@@ -0,0 +1,19 @@
+// Package sketch provides mergeable & serializable probabilistic data structures
+// to analyze the activity in bounded memory, i.e. HyperLogLog for distinct counts,
+// Count-Min sketch for frequencies & a top-K tracker for heavy hitters.
+package sketch
+
+import "hash/fnv"
+
+// hash64 returns a well mixed 64 bit hash of the key (FNV-1a followed by the murmur3 finalizer)
+func hash64(key string) uint64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(key))
+	x := h.Sum64()
+	x ^= x >> 33
+	x *= 0xff51afd7ed558ccd
+	x ^= x >> 33
+	x *= 0xc4ceb9fe1a85ec53
+	x ^= x >> 33
+	return x
+}
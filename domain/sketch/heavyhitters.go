@@ -0,0 +1,196 @@
+package sketch
+
+import (
+	"container/heap"
+	"errors"
+
+	"github.com/ameykpatil/github-data-analyzer/domain/topk"
+)
+
+// Item is a tracked key with its estimated count, Label is a readable name of the key
+type Item struct {
+	Key   string `json:"key"`
+	Label string `json:"label"`
+	Count uint64 `json:"count"`
+}
+
+// HeavyHitters tracks the K keys with the highest estimated counts of a Count-Min sketch
+// a key is tracked once its estimate exceeds the lowest tracked estimate, so memory is bounded by K & the sketch
+// the tracked keys are also kept in a min-heap by estimate, so a key is compared with the lowest one in constant time
+type HeavyHitters struct {
+	K      int             `json:"k"`
+	Sketch *CountMin       `json:"sketch"`
+	Items  map[string]Item `json:"items"`
+	lowest *itemHeap
+}
+
+// NewHeavyHitters creates an empty tracker of k keys backed by a Count-Min sketch of the given width & depth
+func NewHeavyHitters(k int, width, depth uint32) (*HeavyHitters, error) {
+	if k <= 0 {
+		return nil, errors.New("k should be greater than 0")
+	}
+	sketch, err := NewCountMin(width, depth)
+	if err != nil {
+		return nil, err
+	}
+	return &HeavyHitters{
+		K:      k,
+		Sketch: sketch,
+		Items:  map[string]Item{},
+	}, nil
+}
+
+// Validate checks k, the sketch & the tracked keys, e.g. of a decoded tracker
+func (hh *HeavyHitters) Validate() error {
+	if hh.K <= 0 {
+		return errors.New("k should be greater than 0")
+	}
+	if hh.Sketch == nil || hh.Items == nil {
+		return errors.New("sketch & items are required")
+	}
+	if len(hh.Items) > hh.K {
+		return errors.New("number of tracked keys is more than k")
+	}
+	return hh.Sketch.Validate()
+}
+
+// Add adds count to the frequency of the key
+func (hh *HeavyHitters) Add(key, label string, count uint64) {
+	hh.Sketch.Add(key, count)
+	estimate := hh.Sketch.Estimate(key)
+	h := hh.heap()
+	if i, ok := h.index[key]; ok {
+		hh.Items[key] = Item{Key: key, Label: label, Count: estimate}
+		heap.Fix(h, i)
+		return
+	}
+	if len(hh.Items) < hh.K {
+		hh.Items[key] = Item{Key: key, Label: label, Count: estimate}
+		heap.Push(h, key)
+		return
+	}
+
+	// replace the tracked key with the lowest estimate (ties by the largest key) if the key is heavier
+	if lowest := hh.Items[h.keys[0]]; estimate > lowest.Count {
+		delete(hh.Items, lowest.Key)
+		delete(h.index, lowest.Key)
+		hh.Items[key] = Item{Key: key, Label: label, Count: estimate}
+		h.keys[0], h.index[key] = key, 0
+		heap.Fix(h, 0)
+	}
+}
+
+// heap returns the min-heap of the tracked keys, it is built from the items if they were decoded or merged
+func (hh *HeavyHitters) heap() *itemHeap {
+	if hh.lowest != nil {
+		return hh.lowest
+	}
+	h := &itemHeap{items: hh.Items, keys: make([]string, 0, len(hh.Items)), index: make(map[string]int, len(hh.Items))}
+	for key := range hh.Items {
+		h.index[key] = len(h.keys)
+		h.keys = append(h.keys, key)
+	}
+	heap.Init(h)
+	hh.lowest = h
+	return h
+}
+
+// Top returns the tracked keys with the highest estimates based on provided limit & offset, ties are broken by the key
+func (hh *HeavyHitters) Top(limit, offset int) []Item {
+	all := make([]Item, 0, len(hh.Items))
+	for _, item := range hh.Items {
+		all = append(all, item)
+	}
+	indices := topk.Select(itemRanking(all), limit, offset)
+	items := make([]Item, 0, len(indices))
+	for _, i := range indices {
+		items = append(items, all[i])
+	}
+	return items
+}
+
+// Merge merges the other tracker into this one, the union of the tracked keys is re-estimated on the merged sketch
+func (hh *HeavyHitters) Merge(other *HeavyHitters) error {
+	if hh.K != other.K {
+		return errors.New("cannot merge heavy hitters with different k")
+	}
+	if err := hh.Sketch.Merge(other.Sketch); err != nil {
+		return err
+	}
+	for key, item := range other.Items {
+		hh.Items[key] = item
+	}
+	for key, item := range hh.Items {
+		item.Count = hh.Sketch.Estimate(key)
+		hh.Items[key] = item
+	}
+
+	top := hh.Top(hh.K, 0)
+	hh.Items = make(map[string]Item, len(top))
+	for _, item := range top {
+		hh.Items[item.Key] = item
+	}
+	hh.lowest = nil
+	return nil
+}
+
+// itemRanking ranks the items by count (topk Interface)
+type itemRanking []Item
+
+// Len is the number of items to rank
+func (r itemRanking) Len() int {
+	return len(r)
+}
+
+// Less reports whether the item i ranks before the item j
+func (r itemRanking) Less(i, j int) bool {
+	return r[i].Count > r[j].Count
+}
+
+// ID is used to break the ties between items
+func (r itemRanking) ID(i int) string {
+	return r[i].Key
+}
+
+// itemHeap is a min-heap of the tracked keys by estimate, ties by the largest key (heap Interface)
+// index is the position of every key in the heap
+type itemHeap struct {
+	items map[string]Item
+	keys  []string
+	index map[string]int
+}
+
+// Len is the number of tracked keys
+func (h *itemHeap) Len() int {
+	return len(h.keys)
+}
+
+// Less reports whether the key i is lighter than the key j
+func (h *itemHeap) Less(i, j int) bool {
+	a, b := h.items[h.keys[i]], h.items[h.keys[j]]
+	if a.Count == b.Count {
+		return a.Key > b.Key
+	}
+	return a.Count < b.Count
+}
+
+// Swap swaps the keys i & j
+func (h *itemHeap) Swap(i, j int) {
+	h.keys[i], h.keys[j] = h.keys[j], h.keys[i]
+	h.index[h.keys[i]], h.index[h.keys[j]] = i, j
+}
+
+// Push adds the key at the end of the heap
+func (h *itemHeap) Push(x interface{}) {
+	key := x.(string)
+	h.index[key] = len(h.keys)
+	h.keys = append(h.keys, key)
+}
+
+// Pop removes the key at the end of the heap
+func (h *itemHeap) Pop() interface{} {
+	key := h.keys[len(h.keys)-1]
+	h.keys = h.keys[:len(h.keys)-1]
+	delete(h.index, key)
+	return key
+}
@@ -0,0 +1,73 @@
+package sketch
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func keys(items []Item) []string {
+	result := []string{}
+	for _, item := range items {
+		result = append(result, item.Key)
+	}
+	return result
+}
+
+func TestHeavyHitters(t *testing.T) {
+	hh, err := NewHeavyHitters(3, 1024, 5)
+	assert.NoError(t, err)
+	// heavy keys interleaved with a long tail of light keys
+	for i := 0; i < 1000; i++ {
+		hh.Add("heavy1", "Heavy 1", 5)
+		hh.Add("heavy2", "Heavy 2", 3)
+		if i%2 == 0 {
+			hh.Add("heavy3", "Heavy 3", 1)
+		}
+		hh.Add("light"+strconv.Itoa(i), "", 1)
+	}
+
+	top := hh.Top(0, 0)
+	assert.Equal(t, []string{"heavy1", "heavy2", "heavy3"}, keys(top))
+	assert.Equal(t, "Heavy 1", top[0].Label)
+	assert.GreaterOrEqual(t, top[0].Count, uint64(5000))
+	assert.Equal(t, []string{"heavy2"}, keys(hh.Top(1, 1)))
+	assert.Len(t, hh.Items, 3)
+}
+
+func TestHeavyHittersMerge(t *testing.T) {
+	a, _ := NewHeavyHitters(2, 1024, 5)
+	b, _ := NewHeavyHitters(2, 1024, 5)
+	a.Add("x", "", 10)
+	a.Add("y", "", 8)
+	b.Add("z", "", 9)
+	b.Add("y", "", 4)
+
+	assert.NoError(t, a.Merge(b))
+	assert.Equal(t, []Item{{Key: "y", Count: 12}, {Key: "x", Count: 10}}, a.Top(0, 0))
+
+	other, _ := NewHeavyHitters(3, 1024, 5)
+	assert.Error(t, a.Merge(other))
+}
+
+func TestHeavyHittersLowest(t *testing.T) {
+	hh, _ := NewHeavyHitters(2, 1024, 5)
+	hh.Add("a", "", 3)
+	hh.Add("b", "", 5)
+	// c is lighter than the lowest tracked key a & d is heavier, so d replaces a
+	hh.Add("c", "", 2)
+	hh.Add("d", "", 4)
+	assert.Equal(t, []string{"b", "d"}, keys(hh.Top(0, 0)))
+
+	// d becomes the heaviest, so b is the lowest tracked key & the one replaced
+	hh.Add("d", "", 6)
+	hh.Add("e", "", 7)
+	assert.Equal(t, []string{"d", "e"}, keys(hh.Top(0, 0)))
+
+	// the heap is rebuilt after a merge
+	other, _ := NewHeavyHitters(2, 1024, 5)
+	assert.NoError(t, hh.Merge(other))
+	hh.Add("f", "", 8)
+	assert.Equal(t, []string{"d", "f"}, keys(hh.Top(0, 0)))
+}
@@ -0,0 +1,105 @@
+package sketch
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/bits"
+)
+
+// precision bounds of HyperLogLog, i.e. 16 to 262144 registers
+const (
+	MinPrecision = 4
+	MaxPrecision = 18
+)
+
+// HyperLogLog estimates the number of distinct keys with 2^Precision registers of one byte
+// the relative standard error of the estimate is 1.04 / sqrt(2^Precision)
+type HyperLogLog struct {
+	Precision uint8   `json:"precision"`
+	Registers []uint8 `json:"registers"`
+}
+
+// NewHyperLogLog creates an empty HyperLogLog with the given precision
+func NewHyperLogLog(precision uint8) (*HyperLogLog, error) {
+	if precision < MinPrecision || precision > MaxPrecision {
+		return nil, fmt.Errorf("precision should be between %d & %d", MinPrecision, MaxPrecision)
+	}
+	return &HyperLogLog{
+		Precision: precision,
+		Registers: make([]uint8, 1<<precision),
+	}, nil
+}
+
+// Validate checks the precision & the number of registers, e.g. of a decoded HyperLogLog
+func (h *HyperLogLog) Validate() error {
+	if h.Precision < MinPrecision || h.Precision > MaxPrecision {
+		return fmt.Errorf("precision should be between %d & %d", MinPrecision, MaxPrecision)
+	}
+	if len(h.Registers) != 1<<h.Precision {
+		return errors.New("number of registers does not match the precision")
+	}
+	return nil
+}
+
+// Add adds the key to the set
+func (h *HyperLogLog) Add(key string) {
+	x := hash64(key)
+	index := x >> (64 - h.Precision)
+	// the sentinel bit caps the rank at 64 - Precision + 1
+	rank := uint8(bits.LeadingZeros64(x<<h.Precision|1<<(h.Precision-1))) + 1
+	if rank > h.Registers[index] {
+		h.Registers[index] = rank
+	}
+}
+
+// Count returns the estimated number of distinct keys, linear counting is used for small cardinalities
+func (h *HyperLogLog) Count() float64 {
+	m := float64(len(h.Registers))
+	sum := 0.0
+	zeros := 0
+	for _, register := range h.Registers {
+		sum += math.Pow(2, -float64(register))
+		if register == 0 {
+			zeros++
+		}
+	}
+
+	estimate := alpha(len(h.Registers)) * m * m / sum
+	if estimate <= 2.5*m && zeros > 0 {
+		return m * math.Log(m/float64(zeros))
+	}
+	return estimate
+}
+
+// RelativeError returns the relative standard error of the estimate
+func (h *HyperLogLog) RelativeError() float64 {
+	return 1.04 / math.Sqrt(float64(len(h.Registers)))
+}
+
+// Merge merges the other HyperLogLog into this one, both should have the same precision
+func (h *HyperLogLog) Merge(other *HyperLogLog) error {
+	if h.Precision != other.Precision || len(h.Registers) != len(other.Registers) {
+		return errors.New("cannot merge HyperLogLog with different precision")
+	}
+	for i, register := range other.Registers {
+		if register > h.Registers[i] {
+			h.Registers[i] = register
+		}
+	}
+	return nil
+}
+
+// alpha is the bias correction constant for m registers
+func alpha(m int) float64 {
+	switch m {
+	case 16:
+		return 0.673
+	case 32:
+		return 0.697
+	case 64:
+		return 0.709
+	default:
+		return 0.7213 / (1 + 1.079/float64(m))
+	}
+}
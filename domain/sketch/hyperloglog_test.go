@@ -0,0 +1,69 @@
+package sketch
+
+import (
+	"encoding/json"
+	"math"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHyperLogLog(t *testing.T) {
+	for _, n := range []int{0, 10, 1000, 100000} {
+		t.Run(strconv.Itoa(n), func(t *testing.T) {
+			hll, err := NewHyperLogLog(14)
+			assert.NoError(t, err)
+			for i := 0; i < n; i++ {
+				// every key is added twice, duplicates are not counted
+				hll.Add(strconv.Itoa(i))
+				hll.Add(strconv.Itoa(i))
+			}
+			assert.InDelta(t, float64(n), hll.Count(), 3*hll.RelativeError()*float64(n)+1)
+		})
+	}
+}
+
+func TestHyperLogLogMerge(t *testing.T) {
+	a, _ := NewHyperLogLog(12)
+	b, _ := NewHyperLogLog(12)
+	union, _ := NewHyperLogLog(12)
+	for i := 0; i < 20000; i++ {
+		key := strconv.Itoa(i)
+		if i < 15000 {
+			a.Add(key)
+		}
+		if i >= 5000 {
+			b.Add(key)
+		}
+		union.Add(key)
+	}
+	assert.NoError(t, a.Merge(b))
+	assert.Equal(t, union.Count(), a.Count())
+
+	other, _ := NewHyperLogLog(10)
+	assert.Error(t, a.Merge(other))
+}
+
+func TestHyperLogLogSerialization(t *testing.T) {
+	hll, _ := NewHyperLogLog(8)
+	for i := 0; i < 500; i++ {
+		hll.Add(strconv.Itoa(i))
+	}
+	data, err := json.Marshal(hll)
+	assert.NoError(t, err)
+	got := &HyperLogLog{}
+	assert.NoError(t, json.Unmarshal(data, got))
+	assert.Equal(t, hll, got)
+	assert.Equal(t, hll.Count(), got.Count())
+}
+
+func TestNewHyperLogLogPrecision(t *testing.T) {
+	_, err := NewHyperLogLog(MinPrecision - 1)
+	assert.Error(t, err)
+	_, err = NewHyperLogLog(MaxPrecision + 1)
+	assert.Error(t, err)
+	hll, err := NewHyperLogLog(MinPrecision)
+	assert.NoError(t, err)
+	assert.Equal(t, 1.04/math.Sqrt(16), hll.RelativeError())
+}
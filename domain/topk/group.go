@@ -0,0 +1,36 @@
+package topk
+
+// GroupedInterface is implemented by a collection whose top elements are to be selected per group
+type GroupedInterface interface {
+	Interface
+	// Group returns the group of the element i e.g. the repo of a contributor
+	Group(i int) string
+}
+
+// SelectGrouped returns indices of the top elements of every group in the ranked order, keyed by the group
+// at most limit elements are returned per group, limit 0 means all
+// it ranks all the groups in a single pass over the elements with a bounded heap per group
+func SelectGrouped(data GroupedInterface, limit int) map[string][]int {
+	n := data.Len()
+	k := n
+	if limit > 0 && limit < n {
+		k = limit
+	}
+
+	heaps := make(map[string]*boundedHeap)
+	for i := 0; i < n; i++ {
+		group := data.Group(i)
+		h, ok := heaps[group]
+		if !ok {
+			h = &boundedHeap{data: data}
+			heaps[group] = h
+		}
+		h.offer(i, k)
+	}
+
+	groups := make(map[string][]int, len(heaps))
+	for group, h := range heaps {
+		groups[group] = h.ranked()
+	}
+	return groups
+}
@@ -0,0 +1,70 @@
+package topk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type groupedElement struct {
+	element
+	group string
+}
+
+// groupedElements ranks the elements of every group by count in descending order
+type groupedElements []groupedElement
+
+func (e groupedElements) Len() int           { return len(e) }
+func (e groupedElements) Less(i, j int) bool { return e[i].count > e[j].count }
+func (e groupedElements) ID(i int) string    { return e[i].id }
+func (e groupedElements) Group(i int) string { return e[i].group }
+
+func TestSelectGrouped(t *testing.T) {
+	data := groupedElements{
+		{element: element{id: "a", count: 1}, group: "x"},
+		{element: element{id: "b", count: 5}, group: "y"},
+		{element: element{id: "c", count: 3}, group: "x"},
+		{element: element{id: "d", count: 5}, group: "x"},
+		{element: element{id: "e", count: 2}, group: "y"},
+		{element: element{id: "f", count: 3}, group: "x"},
+	}
+
+	tests := []struct {
+		name  string
+		limit int
+		exp   map[string][]string
+	}{
+		{
+			name:  "limit smaller than groups",
+			limit: 2,
+			exp:   map[string][]string{"x": {"d", "c"}, "y": {"b", "e"}},
+		},
+		{
+			name:  "limit one",
+			limit: 1,
+			exp:   map[string][]string{"x": {"d"}, "y": {"b"}},
+		},
+		{
+			name:  "limit zero returns all",
+			limit: 0,
+			exp:   map[string][]string{"x": {"d", "c", "f", "a"}, "y": {"b", "e"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			groups := SelectGrouped(data, tt.limit)
+			ids := make(map[string][]string, len(groups))
+			for group, indices := range groups {
+				for _, i := range indices {
+					ids[group] = append(ids[group], data[i].id)
+				}
+			}
+			assert.Equal(t, tt.exp, ids)
+		})
+	}
+}
+
+func TestSelectGroupedEmpty(t *testing.T) {
+	assert.Empty(t, SelectGrouped(groupedElements{}, 3))
+}
@@ -0,0 +1,120 @@
+package topk
+
+import "errors"
+
+// Mode decides how the tied elements are ranked
+type Mode string
+
+// rank modes
+const (
+	// Competition gives the tied elements the same rank & leaves a gap after them i.e. 1, 2, 2, 4
+	Competition Mode = "competition"
+	// Dense gives the tied elements the same rank without a gap i.e. 1, 2, 2, 3
+	Dense Mode = "dense"
+	// Ordinal gives every element a distinct rank in the order of the tie breaker i.e. 1, 2, 3, 4
+	Ordinal Mode = "ordinal"
+)
+
+// TieBreaker decides the order of the tied elements
+type TieBreaker string
+
+// tie breakers
+const (
+	// ByID orders the tied elements by id
+	ByID TieBreaker = "id"
+	// ByName orders the tied elements by name & then by id
+	ByName TieBreaker = "name"
+)
+
+// Options to configure the ranking, the zero value is competition ranking with the ties broken by id
+type Options struct {
+	Mode       Mode
+	TieBreaker TieBreaker
+}
+
+// ParseOptions returns the options for the given rank mode & tie breaker, empty values mean the defaults
+func ParseOptions(mode, tieBreaker string) (Options, error) {
+	options := Options{Mode: Mode(mode), TieBreaker: TieBreaker(tieBreaker)}
+	switch options.Mode {
+	case "":
+		options.Mode = Competition
+	case Competition, Dense, Ordinal:
+	default:
+		return Options{}, errors.New("invalid rank mode " + mode + ", valid ones are competition, dense or ordinal")
+	}
+	switch options.TieBreaker {
+	case "":
+		options.TieBreaker = ByID
+	case ByID, ByName:
+	default:
+		return Options{}, errors.New("invalid tie breaker " + tieBreaker + ", valid ones are id or name")
+	}
+	return options, nil
+}
+
+// TieKey returns the key used as ID of an element to break the ties with the tie breaker
+func TieKey(tieBreaker TieBreaker, id, name string) string {
+	if tieBreaker == ByName {
+		// the separator sorts a name before the longer names it is a prefix of
+		return name + "\x00" + id
+	}
+	return id
+}
+
+// Ranked is a selected element with its rank, Tie is true if another element has the same sort key
+type Ranked struct {
+	Index int
+	Rank  int
+	Tie   bool
+}
+
+// SelectRanked returns the top elements like Select along with their rank according to the mode
+// the elements skipped by the offset are kept in the heap as well so that the ranks are not relative to the page
+func SelectRanked(data Interface, limit, offset int, mode Mode) []Ranked {
+	k := 0
+	if limit > 0 {
+		k = offset + limit
+	}
+	prefix := Select(data, k, 0)
+
+	ranked := make([]Ranked, len(prefix))
+	for p, i := range prefix {
+		ranked[p] = Ranked{Index: i, Rank: p + 1}
+		if p == 0 {
+			continue
+		}
+		if equal(data, prefix[p-1], i) {
+			ranked[p-1].Tie = true
+			ranked[p].Tie = true
+			if mode != Ordinal {
+				ranked[p].Rank = ranked[p-1].Rank
+			}
+		} else if mode == Dense {
+			ranked[p].Rank = ranked[p-1].Rank + 1
+		}
+	}
+
+	// the last selected element may tie with an element which is not selected
+	if n := len(prefix); n > 0 && n < data.Len() && !ranked[n-1].Tie {
+		selected := make(map[int]bool, n)
+		for _, i := range prefix {
+			selected[i] = true
+		}
+		for j := 0; j < data.Len(); j++ {
+			if !selected[j] && equal(data, prefix[n-1], j) {
+				ranked[n-1].Tie = true
+				break
+			}
+		}
+	}
+
+	if offset >= len(ranked) {
+		return []Ranked{}
+	}
+	return ranked[offset:]
+}
+
+// equal reports whether the elements i & j have the same sort key
+func equal(data Interface, i, j int) bool {
+	return !data.Less(i, j) && !data.Less(j, i)
+}
@@ -0,0 +1,89 @@
+package topk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSelectRanked(t *testing.T) {
+	// b & d tie on 5, c & f tie on 3
+	data := elements{
+		{id: "a", count: 1},
+		{id: "b", count: 5},
+		{id: "c", count: 3},
+		{id: "d", count: 5},
+		{id: "e", count: 4},
+		{id: "f", count: 3},
+	}
+
+	tests := []struct {
+		name   string
+		mode   Mode
+		limit  int
+		offset int
+		exp    []Ranked
+	}{
+		{
+			name: "competition",
+			mode: Competition,
+			exp:  []Ranked{{1, 1, true}, {3, 1, true}, {4, 3, false}, {2, 4, true}, {5, 4, true}, {0, 6, false}},
+		},
+		{
+			name: "dense",
+			mode: Dense,
+			exp:  []Ranked{{1, 1, true}, {3, 1, true}, {4, 2, false}, {2, 3, true}, {5, 3, true}, {0, 4, false}},
+		},
+		{
+			name: "ordinal",
+			mode: Ordinal,
+			exp:  []Ranked{{1, 1, true}, {3, 2, true}, {4, 3, false}, {2, 4, true}, {5, 5, true}, {0, 6, false}},
+		},
+		{
+			name:   "ranks are not relative to the page",
+			mode:   Dense,
+			limit:  2,
+			offset: 1,
+			exp:    []Ranked{{3, 1, true}, {4, 2, false}},
+		},
+		{
+			name:  "tie with an element which is not selected",
+			mode:  Competition,
+			limit: 4,
+			exp:   []Ranked{{1, 1, true}, {3, 1, true}, {4, 3, false}, {2, 4, true}},
+		},
+		{
+			name:   "offset larger than elements",
+			mode:   Competition,
+			offset: 10,
+			exp:    []Ranked{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.exp, SelectRanked(data, tt.limit, tt.offset, tt.mode))
+		})
+	}
+}
+
+func TestParseOptions(t *testing.T) {
+	options, err := ParseOptions("", "")
+	assert.NoError(t, err)
+	assert.Equal(t, Options{Mode: Competition, TieBreaker: ByID}, options)
+
+	options, err = ParseOptions("dense", "name")
+	assert.NoError(t, err)
+	assert.Equal(t, Options{Mode: Dense, TieBreaker: ByName}, options)
+
+	_, err = ParseOptions("fractional", "id")
+	assert.Error(t, err)
+	_, err = ParseOptions("ordinal", "age")
+	assert.Error(t, err)
+}
+
+func TestTieKey(t *testing.T) {
+	assert.Equal(t, "2", TieKey(ByID, "2", "name"))
+	// a name sorts before the longer names it is a prefix of, whatever the ids
+	assert.True(t, TieKey(ByName, "9", "ab") < TieKey(ByName, "1", "abc"))
+	assert.True(t, TieKey(ByName, "1", "ab") < TieKey(ByName, "2", "ab"))
+}
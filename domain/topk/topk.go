@@ -0,0 +1,104 @@
+// Package topk selects the top elements of a collection using a bounded heap
+package topk
+
+import (
+	"container/heap"
+)
+
+// Interface is implemented by a collection whose top elements are to be selected
+type Interface interface {
+	// Len is the number of elements in the collection
+	Len() int
+	// Less reports whether the element i ranks before the element j
+	Less(i, j int) bool
+	// ID returns the unique identifier of the element i, it is used to break the ties
+	ID(i int) string
+}
+
+// Select returns indices of the top elements in the ranked order
+// offset number of top elements are skipped & at most limit elements are returned, limit 0 means all
+// it keeps only offset+limit elements in a min-heap so it runs in O(n log k) instead of O(n log n)
+func Select(data Interface, limit, offset int) []int {
+	n := data.Len()
+	if offset >= n {
+		return []int{}
+	}
+
+	k := n
+	if limit > 0 && offset+limit < n {
+		k = offset + limit
+	}
+
+	h := &boundedHeap{data: data}
+	for i := 0; i < n; i++ {
+		h.offer(i, k)
+	}
+
+	return h.ranked()[offset:]
+}
+
+// before reports whether the element i ranks before the element j, ties are broken by the ID
+func before(data Interface, i, j int) bool {
+	if data.Less(i, j) {
+		return true
+	} else if data.Less(j, i) {
+		return false
+	}
+	return data.ID(i) < data.ID(j)
+}
+
+// boundedHeap is a min-heap of indices where the root is the worst ranked element kept so far
+type boundedHeap struct {
+	data    Interface
+	indices []int
+}
+
+// offer adds the element i to the heap if it has less than k elements or i ranks before the worst one kept so far
+func (h *boundedHeap) offer(i, k int) {
+	if h.Len() < k {
+		heap.Push(h, i)
+	} else if before(h.data, i, h.indices[0]) {
+		h.indices[0] = i
+		heap.Fix(h, 0)
+	}
+}
+
+// ranked empties the heap & returns the indices of its elements in the ranked order
+func (h *boundedHeap) ranked() []int {
+	// popping the min-heap gives the elements from worst to best
+	indices := make([]int, h.Len())
+	for i := len(indices) - 1; i >= 0; i-- {
+		indices[i] = heap.Pop(h).(int)
+	}
+	return indices
+}
+
+// Swap is required to swap the elements of the heap (Sort interface)
+func (h boundedHeap) Swap(i, j int) {
+	h.indices[i], h.indices[j] = h.indices[j], h.indices[i]
+}
+
+// Len is required to know the length (Sort interface)
+func (h boundedHeap) Len() int {
+	return len(h.indices)
+}
+
+// Less defines the way to determine lesser element (Sort interface)
+// the element ranked later is the lesser one so that it stays at the root
+func (h boundedHeap) Less(i, j int) bool {
+	return before(h.data, h.indices[j], h.indices[i])
+}
+
+// Push adds an element to heap (Heap interface)
+func (h *boundedHeap) Push(x interface{}) {
+	h.indices = append(h.indices, x.(int))
+}
+
+// Pop take out the top element from the heap (Heap interface)
+func (h *boundedHeap) Pop() interface{} {
+	old := h.indices
+	n := len(old)
+	x := old[n-1]
+	h.indices = old[0 : n-1]
+	return x
+}
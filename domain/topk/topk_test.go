@@ -0,0 +1,155 @@
+package topk
+
+import (
+	"container/heap"
+	"math/rand"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type element struct {
+	id    string
+	count int
+}
+
+// elements ranks the elements by count in descending order
+type elements []element
+
+func (e elements) Len() int           { return len(e) }
+func (e elements) Less(i, j int) bool { return e[i].count > e[j].count }
+func (e elements) ID(i int) string    { return e[i].id }
+
+func TestSelect(t *testing.T) {
+	data := elements{
+		{id: "a", count: 1},
+		{id: "b", count: 5},
+		{id: "c", count: 3},
+		{id: "d", count: 5},
+		{id: "e", count: 2},
+	}
+
+	tests := []struct {
+		name   string
+		limit  int
+		offset int
+		exp    []string
+	}{
+		{
+			name:  "limit smaller than elements",
+			limit: 3,
+			exp:   []string{"b", "d", "c"},
+		},
+		{
+			name:  "limit larger than elements",
+			limit: 10,
+			exp:   []string{"b", "d", "c", "e", "a"},
+		},
+		{
+			name:  "limit zero returns all",
+			limit: 0,
+			exp:   []string{"b", "d", "c", "e", "a"},
+		},
+		{
+			name:   "offset with limit",
+			limit:  2,
+			offset: 1,
+			exp:    []string{"d", "c"},
+		},
+		{
+			name:   "offset with limit beyond elements",
+			limit:  4,
+			offset: 3,
+			exp:    []string{"e", "a"},
+		},
+		{
+			name:   "offset beyond elements",
+			limit:  2,
+			offset: 5,
+			exp:    []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := []string{}
+			for _, i := range Select(data, tt.limit, tt.offset) {
+				got = append(got, data[i].id)
+			}
+			assert.Equal(t, tt.exp, got)
+		})
+	}
+}
+
+func TestSelectTiesAreDeterministic(t *testing.T) {
+	data := elements{}
+	for i := 0; i < 100; i++ {
+		data = append(data, element{id: strconv.Itoa(1000 + i), count: i % 3})
+	}
+	exp := []string{"1002", "1005", "1008", "1011", "1014", "1017", "1020", "1023", "1026", "1029"}
+
+	// the result must not depend on the order of the input
+	for i := 0; i < 10; i++ {
+		rand.Shuffle(len(data), func(i, j int) { data[i], data[j] = data[j], data[i] })
+		got := []string{}
+		for _, i := range Select(data, 10, 0) {
+			got = append(got, data[i].id)
+		}
+		assert.Equal(t, exp, got)
+	}
+}
+
+// unboundedHeap is the earlier implementation where every element is pushed to the heap
+type unboundedHeap struct {
+	elements
+}
+
+func (h unboundedHeap) Less(i, j int) bool { return h.elements.Less(i, j) }
+
+func (h *unboundedHeap) Push(x interface{}) {
+	h.elements = append(h.elements, x.(element))
+}
+
+func (h *unboundedHeap) Pop() interface{} {
+	old := h.elements
+	n := len(old)
+	x := old[n-1]
+	h.elements = old[0 : n-1]
+	return x
+}
+
+func (h unboundedHeap) Swap(i, j int) {
+	h.elements[i], h.elements[j] = h.elements[j], h.elements[i]
+}
+
+func benchmarkData(n int) elements {
+	r := rand.New(rand.NewSource(1))
+	data := make(elements, n)
+	for i := range data {
+		data[i] = element{id: strconv.Itoa(i), count: r.Intn(n)}
+	}
+	return data
+}
+
+func BenchmarkSelect(b *testing.B) {
+	data := benchmarkData(100000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Select(data, 10, 0)
+	}
+}
+
+func BenchmarkUnboundedHeap(b *testing.B) {
+	data := benchmarkData(100000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		h := &unboundedHeap{}
+		heap.Init(h)
+		for _, e := range data {
+			heap.Push(h, e)
+		}
+		for j := 0; j < 10; j++ {
+			heap.Pop(h)
+		}
+	}
+}
@@ -1,8 +1,7 @@
 package user
 
 import (
-	"container/heap"
-
+	"github.com/ameykpatil/github-data-analyzer/domain/topk"
 	"github.com/ameykpatil/github-data-analyzer/service"
 )
 
@@ -10,6 +9,7 @@ import (
 type Analyzer struct {
 	eventHandler service.EventHandler
 	userMap      map[string]*User
+	activityMap  map[string]map[string]*RepoActivity
 }
 
 // NewAnalyzer creates a new instance of user Analyzer
@@ -17,12 +17,60 @@ func NewAnalyzer(eventHandler service.EventHandler) *Analyzer {
 	return &Analyzer{
 		eventHandler: eventHandler,
 		userMap:      indexUsers(eventHandler),
+		activityMap:  indexRepoActivities(eventHandler),
+	}
+}
+
+// indexRepoActivities creates map of repo activities (by repo id) per user (by user id) from the events
+func indexRepoActivities(eventHandler service.EventHandler) map[string]map[string]*RepoActivity {
+	activityMap := make(map[string]map[string]*RepoActivity)
+	for _, event := range eventHandler.Events {
+		if event.Actor == nil || event.Repo == nil {
+			continue
+		}
+		if activityMap[event.Actor.ID] == nil {
+			activityMap[event.Actor.ID] = map[string]*RepoActivity{}
+		}
+		activity, ok := activityMap[event.Actor.ID][event.Repo.ID]
+		if !ok {
+			activity = &RepoActivity{
+				ID:             event.Repo.ID,
+				Name:           event.Repo.Name,
+				EventTypeCount: map[string]int{},
+			}
+			activityMap[event.Actor.ID][activity.ID] = activity
+		}
+		activity.CommitCount = activity.CommitCount + len(event.Commits)
+		activity.EventCount++
+		activity.EventTypeCount[event.Type] = activity.EventTypeCount[event.Type] + 1
+	}
+	return activityMap
+}
+
+// distinctKey identifies a sha or a repo counted for a user, a scope ("" for all) & an event type ("" for all)
+type distinctKey struct {
+	userID    string
+	scope     string
+	eventType string
+	value     string
+}
+
+// isNew adds the key to the set & reports whether it was not in the set yet
+func isNew(set map[distinctKey]bool, key distinctKey) bool {
+	if set[key] {
+		return false
 	}
+	set[key] = true
+	return true
 }
 
 // indexRepos creates map of users from the events
+// an event is own if the repo is owned by the user & external otherwise, events without repo are neither
 func indexUsers(eventHandler service.EventHandler) map[string]*User {
 	userMap := make(map[string]*User)
+	// shas & repos seen so far, used to count distinct commits & repos
+	shas := make(map[distinctKey]bool)
+	repos := make(map[distinctKey]bool)
 	for _, event := range eventHandler.Events {
 		if event.Actor == nil {
 			continue
@@ -30,31 +78,170 @@ func indexUsers(eventHandler service.EventHandler) map[string]*User {
 		user, ok := userMap[event.Actor.ID]
 		if !ok {
 			user = &User{
-				ID:             event.Actor.ID,
-				Username:       event.Actor.Username,
-				EventTypeCount: map[string]int{},
+				ID:                 event.Actor.ID,
+				Username:           event.Actor.Username,
+				EventTypeCount:     map[string]int{},
+				EventTypeRepoCount: map[string]int{},
+				Own:                Activity{EventTypeCount: map[string]int{}, EventTypeRepoCount: map[string]int{}},
+				External:           Activity{EventTypeCount: map[string]int{}, EventTypeRepoCount: map[string]int{}},
 			}
 			userMap[user.ID] = user
 		}
 		user.CommitCount = user.CommitCount + len(event.Commits)
+		for _, commit := range event.Commits {
+			if isNew(shas, distinctKey{userID: user.ID, value: commit.Sha}) {
+				user.DistinctCommitCount++
+			}
+		}
 		user.EventTypeCount[event.Type] = user.EventTypeCount[event.Type] + 1
+		if event.Repo == nil {
+			continue
+		}
+		if isNew(repos, distinctKey{userID: user.ID, value: event.Repo.ID}) {
+			user.RepoCount++
+		}
+		if isNew(repos, distinctKey{userID: user.ID, eventType: event.Type, value: event.Repo.ID}) {
+			user.EventTypeRepoCount[event.Type] = user.EventTypeRepoCount[event.Type] + 1
+		}
+
+		activity, scope := &user.External, ExternalScope
+		if service.IsOwnRepo(event.Actor.Username, event.Repo.Name) {
+			activity, scope = &user.Own, OwnScope
+		}
+		activity.CommitCount = activity.CommitCount + len(event.Commits)
+		for _, commit := range event.Commits {
+			if isNew(shas, distinctKey{userID: user.ID, scope: scope, value: commit.Sha}) {
+				activity.DistinctCommitCount++
+			}
+		}
+		activity.EventTypeCount[event.Type] = activity.EventTypeCount[event.Type] + 1
+		if isNew(repos, distinctKey{userID: user.ID, scope: scope, value: event.Repo.ID}) {
+			activity.RepoCount++
+		}
+		if isNew(repos, distinctKey{userID: user.ID, scope: scope, eventType: event.Type, value: event.Repo.ID}) {
+			activity.EventTypeRepoCount[event.Type] = activity.EventTypeRepoCount[event.Type] + 1
+		}
 	}
 
 	return userMap
 }
 
-// GetTopUsers returns top users based on provided limit & sort function
-func (ua *Analyzer) GetTopUsers(limit uint32, fn func(i, j User) bool) []User {
-	h := &userHeap{less: fn}
-	heap.Init(h)
+// SetInfluence sets the influence scores (by user id) of the users, the users without a score get 0
+func (ua *Analyzer) SetInfluence(scores map[string]float64) {
+	for id, user := range ua.userMap {
+		user.Influence = scores[id]
+	}
+}
+
+// GetTopUsers returns top users based on provided limit, offset & sort function
+// limit 0 returns all the users, ties are broken by the id
+func (ua *Analyzer) GetTopUsers(limit, offset uint32, fn func(i, j User) bool) []User {
+	ranked := ua.GetRankedUsers(limit, offset, fn, topk.Options{})
+	users := make([]User, 0, len(ranked))
+	for _, r := range ranked {
+		users = append(users, r.User)
+	}
+	return users
+}
+
+// GetRankedUsers returns top users with their rank based on provided limit, offset, sort function & ranking options
+// limit 0 returns all the users
+func (ua *Analyzer) GetRankedUsers(limit, offset uint32, fn func(i, j User) bool, options topk.Options) []RankedUser {
+	return ua.GetRankedUsersWhere(limit, offset, fn, options, nil)
+}
+
+// GetRankedUsersWhere returns top users like GetRankedUsers but ranks only the users the keep function is true for
+// nil keep function keeps all the users
+func (ua *Analyzer) GetRankedUsersWhere(limit, offset uint32, fn func(i, j User) bool, options topk.Options, keep func(u User) bool) []RankedUser {
+	all := make([]User, 0, len(ua.userMap))
 	for _, user := range ua.userMap {
-		heap.Push(h, *user)
+		if keep == nil || keep(*user) {
+			all = append(all, *user)
+		}
 	}
 
-	var users []User
-	for i := uint32(0); i < limit; i++ {
-		users = append(users, heap.Pop(h).(User))
+	ranking := userRanking{users: all, less: fn, tieBreaker: options.TieBreaker}
+	ranked := topk.SelectRanked(ranking, int(limit), int(offset), options.Mode)
+	users := make([]RankedUser, 0, len(ranked))
+	for _, r := range ranked {
+		users = append(users, RankedUser{User: all[r.Index], Rank: r.Rank, Tie: r.Tie})
 	}
 
 	return users
 }
+
+// GetUsers returns all the users ordered by id
+func (ua *Analyzer) GetUsers() []User {
+	return ua.GetTopUsers(0, 0, func(i, j User) bool {
+		return false
+	})
+}
+
+// GetUser returns the user with the given id or username
+func (ua *Analyzer) GetUser(idOrUsername string) (User, bool) {
+	if user, ok := ua.userMap[idOrUsername]; ok {
+		return *user, true
+	}
+	var found *User
+	for _, user := range ua.userMap {
+		if user.Username == idOrUsername && (found == nil || user.ID < found.ID) {
+			found = user
+		}
+	}
+	if found == nil {
+		return User{}, false
+	}
+	return *found, true
+}
+
+// GetRank returns the rank of the given user among all the users based on provided sort function
+func (ua *Analyzer) GetRank(user User, fn func(i, j User) bool) Rank {
+	before, others := 0, 0
+	for _, other := range ua.userMap {
+		if other.ID == user.ID {
+			continue
+		}
+		others++
+		if fn(*other, user) {
+			before++
+		}
+	}
+	rank := Rank{Rank: before + 1, Total: len(ua.userMap)}
+	if rank.Total > 0 {
+		rank.Percentile = 100 * float64(others-before) / float64(rank.Total)
+	}
+	return rank
+}
+
+// GetRepoActivities returns the repos the user was active on, ranked by the number of events & then commits
+func (ua *Analyzer) GetRepoActivities(userID string) []RepoActivity {
+	return ua.GetTopReposPerUser([]string{userID}, 0)[userID]
+}
+
+// GetTopReposPerUser returns the top repos each of the given users was active on by the number of events & then commits,
+// keyed by the user id, limit is the number of repos per user, 0 returns all
+// the activities of all the given users are ranked in a single pass
+func (ua *Analyzer) GetTopReposPerUser(userIDs []string, limit uint32) map[string][]RepoActivity {
+	ranking := groupedRepoActivityRanking{}
+	seen := make(map[string]bool, len(userIDs))
+	for _, userID := range userIDs {
+		if seen[userID] {
+			continue
+		}
+		seen[userID] = true
+		for _, activity := range ua.activityMap[userID] {
+			ranking.activities = append(ranking.activities, *activity)
+			ranking.userIDs = append(ranking.userIDs, userID)
+		}
+	}
+	groups := topk.SelectGrouped(ranking, int(limit))
+	activitiesPerUser := make(map[string][]RepoActivity, len(userIDs))
+	for _, userID := range userIDs {
+		activities := make([]RepoActivity, 0, len(groups[userID]))
+		for _, i := range groups[userID] {
+			activities = append(activities, ranking.activities[i])
+		}
+		activitiesPerUser[userID] = activities
+	}
+	return activitiesPerUser
+}
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/ameykpatil/github-data-analyzer/db/entities"
+	"github.com/ameykpatil/github-data-analyzer/domain/topk"
 	"github.com/ameykpatil/github-data-analyzer/service"
 	"github.com/stretchr/testify/assert"
 )
@@ -32,6 +33,11 @@ var (
 		Message: "Message 3",
 		EventID: "332",
 	}
+	commit4 = entities.Commit{
+		Sha:     "223",
+		Message: "Message 3",
+		EventID: "333",
+	}
 	event1 = entities.Event{
 		ID:      "331",
 		Type:    "PullRequestEvent",
@@ -76,36 +82,60 @@ func TestIndexRepos(t *testing.T) {
 		{
 			name: "single commit & event for users",
 			events: map[string]*service.Event{
-				event1.ID: {event1.ID, event1.Type, &actor1, &repo1, []entities.Commit{commit1}},
-				event2.ID: {event2.ID, event2.Type, &actor2, &repo2, []entities.Commit{commit2}},
+				event1.ID: {ID: event1.ID, Type: event1.Type, Actor: &actor1, Repo: &repo1, Commits: []entities.Commit{commit1}},
+				event2.ID: {ID: event2.ID, Type: event2.Type, Actor: &actor2, Repo: &repo2, Commits: []entities.Commit{commit2}},
 			},
 			exp: map[string]*User{
-				actor1.ID: {actor1.ID, actor1.Username, 1, map[string]int{"PullRequestEvent": 1}},
-				actor2.ID: {actor2.ID, actor2.Username, 1, map[string]int{"ForkEvent": 1}},
+				actor1.ID: {ID: actor1.ID, Username: actor1.Username, CommitCount: 1, DistinctCommitCount: 1, RepoCount: 1, EventTypeCount: map[string]int{"PullRequestEvent": 1}},
+				actor2.ID: {ID: actor2.ID, Username: actor2.Username, CommitCount: 1, DistinctCommitCount: 1, RepoCount: 1, EventTypeCount: map[string]int{"ForkEvent": 1}},
 			},
 		},
 		{
 			name: "multiple commits for users",
 			events: map[string]*service.Event{
-				event1.ID: {event1.ID, event1.Type, &actor1, &repo1, []entities.Commit{commit1}},
-				event2.ID: {event2.ID, event2.Type, &actor2, &repo2, []entities.Commit{commit2, commit3}},
+				event1.ID: {ID: event1.ID, Type: event1.Type, Actor: &actor1, Repo: &repo1, Commits: []entities.Commit{commit1}},
+				event2.ID: {ID: event2.ID, Type: event2.Type, Actor: &actor2, Repo: &repo2, Commits: []entities.Commit{commit2, commit3}},
 			},
 			exp: map[string]*User{
-				actor1.ID: {actor1.ID, actor1.Username, 1, map[string]int{"PullRequestEvent": 1}},
-				actor2.ID: {actor2.ID, actor2.Username, 2, map[string]int{"ForkEvent": 1}},
+				actor1.ID: {ID: actor1.ID, Username: actor1.Username, CommitCount: 1, DistinctCommitCount: 1, RepoCount: 1, EventTypeCount: map[string]int{"PullRequestEvent": 1}},
+				actor2.ID: {ID: actor2.ID, Username: actor2.Username, CommitCount: 2, DistinctCommitCount: 2, RepoCount: 1, EventTypeCount: map[string]int{"ForkEvent": 1}},
 			},
 		},
 		{
 			name: "multiple events for repo",
 			events: map[string]*service.Event{
-				event1.ID: {event1.ID, event1.Type, &actor1, &repo1, []entities.Commit{commit1}},
-				event2.ID: {event2.ID, event2.Type, &actor2, &repo2, []entities.Commit{commit2}},
-				event3.ID: {event3.ID, event3.Type, &actor2, &repo2, []entities.Commit{}},
-				event4.ID: {event4.ID, event4.Type, &actor2, &repo2, []entities.Commit{}},
+				event1.ID: {ID: event1.ID, Type: event1.Type, Actor: &actor1, Repo: &repo1, Commits: []entities.Commit{commit1}},
+				event2.ID: {ID: event2.ID, Type: event2.Type, Actor: &actor2, Repo: &repo2, Commits: []entities.Commit{commit2}},
+				event3.ID: {ID: event3.ID, Type: event3.Type, Actor: &actor2, Repo: &repo2, Commits: []entities.Commit{}},
+				event4.ID: {ID: event4.ID, Type: event4.Type, Actor: &actor2, Repo: &repo2, Commits: []entities.Commit{}},
 			},
 			exp: map[string]*User{
-				actor1.ID: {actor1.ID, actor1.Username, 1, map[string]int{"PullRequestEvent": 1}},
-				actor2.ID: {actor2.ID, actor2.Username, 1, map[string]int{"ForkEvent": 2, "DeleteEvent": 1}},
+				actor1.ID: {ID: actor1.ID, Username: actor1.Username, CommitCount: 1, DistinctCommitCount: 1, RepoCount: 1, EventTypeCount: map[string]int{"PullRequestEvent": 1}},
+				actor2.ID: {ID: actor2.ID, Username: actor2.Username, CommitCount: 1, DistinctCommitCount: 1, RepoCount: 1, EventTypeCount: map[string]int{"ForkEvent": 2, "DeleteEvent": 1}},
+			},
+		},
+		{
+			name: "distinct repos per event type",
+			events: map[string]*service.Event{
+				event2.ID: {ID: event2.ID, Type: event2.Type, Actor: &actor2, Repo: &repo2, Commits: []entities.Commit{}},
+				event3.ID: {ID: event3.ID, Type: event3.Type, Actor: &actor2, Repo: &repo2, Commits: []entities.Commit{}},
+				event4.ID: {ID: event4.ID, Type: event4.Type, Actor: &actor2, Repo: &repo1, Commits: []entities.Commit{}},
+			},
+			exp: map[string]*User{
+				actor2.ID: {ID: actor2.ID, Username: actor2.Username, RepoCount: 2, EventTypeCount: map[string]int{"ForkEvent": 2, "DeleteEvent": 1},
+					EventTypeRepoCount: map[string]int{"ForkEvent": 1, "DeleteEvent": 1}},
+			},
+		},
+		{
+			name: "same commit pushed multiple times",
+			events: map[string]*service.Event{
+				event1.ID: {ID: event1.ID, Type: event1.Type, Actor: &actor1, Repo: &repo1, Commits: []entities.Commit{commit1}},
+				event2.ID: {ID: event2.ID, Type: event2.Type, Actor: &actor2, Repo: &repo2, Commits: []entities.Commit{commit2, commit3}},
+				event3.ID: {ID: event3.ID, Type: event3.Type, Actor: &actor2, Repo: &repo2, Commits: []entities.Commit{commit4}},
+			},
+			exp: map[string]*User{
+				actor1.ID: {ID: actor1.ID, Username: actor1.Username, CommitCount: 1, DistinctCommitCount: 1, RepoCount: 1, EventTypeCount: map[string]int{"PullRequestEvent": 1}},
+				actor2.ID: {ID: actor2.ID, Username: actor2.Username, CommitCount: 3, DistinctCommitCount: 2, RepoCount: 1, EventTypeCount: map[string]int{"ForkEvent": 2}},
 			},
 		},
 	}
@@ -118,6 +148,11 @@ func TestIndexRepos(t *testing.T) {
 					assert.Equal(t, v.ID, gotUser.ID)
 					assert.Equal(t, v.Username, gotUser.Username)
 					assert.Equal(t, v.CommitCount, gotUser.CommitCount)
+					assert.Equal(t, v.DistinctCommitCount, gotUser.DistinctCommitCount)
+					assert.Equal(t, v.RepoCount, gotUser.RepoCount)
+					if v.EventTypeRepoCount != nil {
+						assert.EqualValues(t, v.EventTypeRepoCount, gotUser.EventTypeRepoCount)
+					}
 					assert.EqualValues(t, v.EventTypeCount, gotUser.EventTypeCount)
 				} else {
 					t.Errorf("expected user with id %s not in the result", k)
@@ -127,3 +162,139 @@ func TestIndexRepos(t *testing.T) {
 	}
 
 }
+
+func TestIndexUsersOwnActivity(t *testing.T) {
+	ownRepo := entities.Repo{ID: "443", Name: "actor1/Repo3"}
+	events := map[string]*service.Event{
+		event1.ID: {ID: event1.ID, Type: event1.Type, Actor: &actor1, Repo: &repo1, Commits: []entities.Commit{commit1}},
+		event2.ID: {ID: event2.ID, Type: "PushEvent", Actor: &actor1, Repo: &ownRepo, Commits: []entities.Commit{commit2, commit3}},
+		event3.ID: {ID: event3.ID, Type: "PushEvent", Actor: &actor1, Repo: &ownRepo, Commits: []entities.Commit{commit4}},
+		event4.ID: {ID: event4.ID, Type: event4.Type, Actor: &actor1, Repo: nil, Commits: []entities.Commit{}},
+	}
+	got := indexUsers(service.EventHandler{DataStore: nil, Events: events})[actor1.ID]
+
+	assert.Equal(t, Activity{CommitCount: 3, DistinctCommitCount: 2, EventTypeCount: map[string]int{"PushEvent": 2}, RepoCount: 1, EventTypeRepoCount: map[string]int{"PushEvent": 1}}, got.Own)
+	assert.Equal(t, Activity{CommitCount: 1, DistinctCommitCount: 1, EventTypeCount: map[string]int{"PullRequestEvent": 1}, RepoCount: 1, EventTypeRepoCount: map[string]int{"PullRequestEvent": 1}}, got.External)
+
+	external, ok := got.Scoped(ExternalScope)
+	assert.True(t, ok)
+	assert.Equal(t, 1, external.CommitCount)
+	assert.Equal(t, 1, external.RepoCount)
+	assert.Equal(t, map[string]int{"PullRequestEvent": 1}, external.EventTypeCount)
+	_, ok = got.Scoped("Other")
+	assert.False(t, ok)
+}
+
+func TestGetRankedUsers(t *testing.T) {
+	analyzer := &Analyzer{
+		userMap: map[string]*User{
+			"111": {ID: "111", Username: "zed", CommitCount: 2},
+			"112": {ID: "112", Username: "amy", CommitCount: 2},
+			"113": {ID: "113", Username: "bob", CommitCount: 1},
+		},
+	}
+	byCommits := func(ui, uj User) bool {
+		return ui.CommitCount > uj.CommitCount
+	}
+	usernames := func(users []RankedUser) []string {
+		result := []string{}
+		for _, u := range users {
+			result = append(result, u.Username)
+		}
+		return result
+	}
+
+	byID := analyzer.GetRankedUsers(0, 0, byCommits, topk.Options{})
+	assert.Equal(t, []string{"zed", "amy", "bob"}, usernames(byID))
+	assert.Equal(t, []int{1, 1, 3}, []int{byID[0].Rank, byID[1].Rank, byID[2].Rank})
+	assert.Equal(t, []bool{true, true, false}, []bool{byID[0].Tie, byID[1].Tie, byID[2].Tie})
+
+	byName := analyzer.GetRankedUsers(2, 0, byCommits, topk.Options{Mode: topk.Ordinal, TieBreaker: topk.ByName})
+	assert.Equal(t, []string{"amy", "zed"}, usernames(byName))
+	assert.Equal(t, 2, byName[1].Rank)
+
+	// GetTopUsers keeps breaking the ties by id
+	assert.Equal(t, "111", analyzer.GetTopUsers(1, 0, byCommits)[0].ID)
+
+	// the users which are not kept are not ranked
+	notZed := func(u User) bool {
+		return u.Username != "zed"
+	}
+	filtered := analyzer.GetRankedUsersWhere(0, 0, byCommits, topk.Options{}, notZed)
+	assert.Equal(t, []string{"amy", "bob"}, usernames(filtered))
+	assert.Equal(t, []int{1, 2}, []int{filtered[0].Rank, filtered[1].Rank})
+	assert.False(t, filtered[0].Tie)
+}
+
+func TestUserProfile(t *testing.T) {
+	events := map[string]*service.Event{
+		event1.ID: {ID: event1.ID, Type: event1.Type, Actor: &actor1, Repo: &repo1, Commits: []entities.Commit{commit1}},
+		event2.ID: {ID: event2.ID, Type: event2.Type, Actor: &actor2, Repo: &repo2, Commits: []entities.Commit{commit2, commit3}},
+		event3.ID: {ID: event3.ID, Type: event3.Type, Actor: &actor2, Repo: &repo1, Commits: []entities.Commit{}},
+		event4.ID: {ID: event4.ID, Type: event4.Type, Actor: &actor2, Repo: &repo2, Commits: []entities.Commit{}},
+	}
+	analyzer := NewAnalyzer(service.EventHandler{DataStore: nil, Events: events})
+
+	u, ok := analyzer.GetUser("Actor2")
+	assert.True(t, ok)
+	assert.Equal(t, actor2.ID, u.ID)
+	assert.Equal(t, 2, u.RepoCount)
+	_, ok = analyzer.GetUser("Actor3")
+	assert.False(t, ok)
+
+	byCommits := func(ui, uj User) bool {
+		return ui.CommitCount > uj.CommitCount
+	}
+	assert.Equal(t, Rank{Rank: 1, Percentile: 50, Total: 2}, analyzer.GetRank(u, byCommits))
+	byPullRequests := func(ui, uj User) bool {
+		return ui.EventTypeCount["PullRequestEvent"] > uj.EventTypeCount["PullRequestEvent"]
+	}
+	assert.Equal(t, Rank{Rank: 2, Percentile: 0, Total: 2}, analyzer.GetRank(u, byPullRequests))
+
+	assert.Equal(t, []RepoActivity{
+		{ID: repo2.ID, Name: repo2.Name, CommitCount: 2, EventCount: 2, EventTypeCount: map[string]int{"ForkEvent": 1, "DeleteEvent": 1}},
+		{ID: repo1.ID, Name: repo1.Name, CommitCount: 0, EventCount: 1, EventTypeCount: map[string]int{"ForkEvent": 1}},
+	}, analyzer.GetRepoActivities(u.ID))
+}
+
+func TestGetTopReposPerUser(t *testing.T) {
+	events := map[string]*service.Event{
+		event1.ID: {ID: event1.ID, Type: event1.Type, Actor: &actor1, Repo: &repo1, Commits: []entities.Commit{commit1}},
+		event2.ID: {ID: event2.ID, Type: event2.Type, Actor: &actor2, Repo: &repo2, Commits: []entities.Commit{commit2, commit3}},
+		event3.ID: {ID: event3.ID, Type: event3.Type, Actor: &actor2, Repo: &repo1, Commits: []entities.Commit{}},
+		event4.ID: {ID: event4.ID, Type: event4.Type, Actor: &actor2, Repo: &repo2, Commits: []entities.Commit{}},
+	}
+	analyzer := NewAnalyzer(service.EventHandler{DataStore: nil, Events: events})
+
+	got := analyzer.GetTopReposPerUser([]string{actor1.ID, actor2.ID, "unknown"}, 1)
+	assert.Len(t, got, 3)
+	assert.Equal(t, []RepoActivity{
+		{ID: repo1.ID, Name: repo1.Name, CommitCount: 1, EventCount: 1, EventTypeCount: map[string]int{event1.Type: 1}},
+	}, got[actor1.ID])
+	assert.Equal(t, []RepoActivity{
+		{ID: repo2.ID, Name: repo2.Name, CommitCount: 2, EventCount: 2, EventTypeCount: map[string]int{"ForkEvent": 1, "DeleteEvent": 1}},
+	}, got[actor2.ID])
+	assert.Empty(t, got["unknown"])
+
+	// only the given users are aggregated
+	assert.Len(t, analyzer.GetTopReposPerUser([]string{actor2.ID}, 0), 1)
+	assert.Len(t, analyzer.GetTopReposPerUser([]string{actor2.ID}, 0)[actor2.ID], 2)
+	assert.Len(t, analyzer.GetTopReposPerUser([]string{actor2.ID, actor2.ID}, 0)[actor2.ID], 2)
+
+	// the activities are indexed once, the events are not scanned again
+	analyzer.eventHandler.Events = nil
+	assert.Len(t, analyzer.GetTopReposPerUser([]string{actor2.ID}, 0)[actor2.ID], 2)
+}
+
+func TestSetInfluence(t *testing.T) {
+	analyzer := &Analyzer{
+		userMap: map[string]*User{
+			"111": {ID: "111", Username: "zed"},
+			"112": {ID: "112", Username: "amy"},
+		},
+	}
+
+	analyzer.SetInfluence(map[string]float64{"111": 1.5, "999": 2})
+	assert.Equal(t, 1.5, analyzer.userMap["111"].Influence)
+	assert.Equal(t, 0.0, analyzer.userMap["112"].Influence)
+}
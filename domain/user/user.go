@@ -1,47 +1,150 @@
 package user
 
+import "github.com/ameykpatil/github-data-analyzer/domain/topk"
+
 // User encapsulates required properties related to user
+// CommitCount counts every pushed commit whereas DistinctCommitCount counts unique shas
+// RepoCount is the number of distinct repos the user was active on & EventTypeRepoCount the same per event type
+// Own & External split the activity into the one on repos owned by the user & the one on the repos of others
+// Influence is the PageRank based score of the user over the activity graph, it is 0 until it is set
 type User struct {
+	ID                  string
+	Username            string
+	CommitCount         int
+	DistinctCommitCount int
+	EventTypeCount      map[string]int
+	RepoCount           int
+	EventTypeRepoCount  map[string]int
+	Own                 Activity
+	External            Activity
+	Influence           float64
+}
+
+// scopes of the activity of a user
+const (
+	OwnScope      = "Own"
+	ExternalScope = "External"
+)
+
+// Activity encapsulates the counters of a part of the activity of a user
+type Activity struct {
+	CommitCount         int
+	DistinctCommitCount int
+	EventTypeCount      map[string]int
+	RepoCount           int
+	EventTypeRepoCount  map[string]int
+}
+
+// Scoped returns the user with the counters replaced by the ones of the given scope (Own or External)
+// false is returned if the scope is not valid
+func (u User) Scoped(scope string) (User, bool) {
+	var activity Activity
+	switch scope {
+	case OwnScope:
+		activity = u.Own
+	case ExternalScope:
+		activity = u.External
+	default:
+		return u, false
+	}
+	u.CommitCount = activity.CommitCount
+	u.DistinctCommitCount = activity.DistinctCommitCount
+	u.EventTypeCount = activity.EventTypeCount
+	u.RepoCount = activity.RepoCount
+	u.EventTypeRepoCount = activity.EventTypeRepoCount
+	return u, true
+}
+
+// RankedUser is a user with its rank in a top list, Tie is true if another user has the same sort key
+type RankedUser struct {
+	User
+	Rank int
+	Tie  bool
+}
+
+// userRanking ranks the users using the given sort function (topk Interface)
+type userRanking struct {
+	users      []User
+	less       func(i, j User) bool
+	tieBreaker topk.TieBreaker
+}
+
+// Len is the number of users to rank
+func (r userRanking) Len() int {
+	return len(r.users)
+}
+
+// Less reports whether the user i ranks before the user j
+func (r userRanking) Less(i, j int) bool {
+	return r.less(r.users[i], r.users[j])
+}
+
+// ID is used to break the ties between users
+func (r userRanking) ID(i int) string {
+	return topk.TieKey(r.tieBreaker, r.users[i].ID, r.users[i].Username)
+}
+
+// RepoActivity encapsulates the activity of a user on a repo
+type RepoActivity struct {
 	ID             string
-	Username       string
+	Name           string
 	CommitCount    int
+	EventCount     int
 	EventTypeCount map[string]int
 }
 
-// base heap structure for User
-type baseUserHeap []User
-
-// Swap is required to swap the elements of the heap (Sort interface)
-func (r baseUserHeap) Swap(i, j int) {
-	r[i], r[j] = r[j], r[i]
+// Rank encapsulates the position of a user among all the users under a sort function
+// Rank is 1 + number of users ranked strictly before, Percentile is the share of the other users
+// not ranked before i.e. ranked after or tied, so it matches the rank
+type Rank struct {
+	Rank       int
+	Percentile float64
+	Total      int
 }
 
-// Len is required to know the length (Sort interface)
-func (r baseUserHeap) Len() int {
+// repoActivityRanking ranks the repo activities by events & then commits (topk Interface)
+type repoActivityRanking []RepoActivity
+
+// Len is the number of repo activities to rank
+func (r repoActivityRanking) Len() int {
 	return len(r)
 }
 
-// Push adds an element to heap (Heap interface)
-func (r *baseUserHeap) Push(x interface{}) {
-	*r = append(*r, x.(User))
+// Less reports whether the repo activity i ranks before the repo activity j
+func (r repoActivityRanking) Less(i, j int) bool {
+	if r[i].EventCount == r[j].EventCount {
+		return r[i].CommitCount > r[j].CommitCount
+	}
+	return r[i].EventCount > r[j].EventCount
+}
+
+// ID is used to break the ties between repo activities
+func (r repoActivityRanking) ID(i int) string {
+	return r[i].ID
+}
+
+// groupedRepoActivityRanking ranks the repo activities of every user by events & then commits (topk GroupedInterface)
+type groupedRepoActivityRanking struct {
+	activities []RepoActivity
+	userIDs    []string
+}
+
+// Len is the number of repo activities to rank
+func (r groupedRepoActivityRanking) Len() int {
+	return len(r.activities)
 }
 
-// Pop take out the top element from the heap (Heap interface)
-func (r *baseUserHeap) Pop() interface{} {
-	old := *r
-	n := len(old)
-	x := old[n-1]
-	*r = old[0 : n-1]
-	return x
+// Less reports whether the repo activity i ranks before the repo activity j
+func (r groupedRepoActivityRanking) Less(i, j int) bool {
+	return repoActivityRanking(r.activities).Less(i, j)
 }
 
-// concrete heap structure for user
-type userHeap struct {
-	baseUserHeap
-	less func(i, j User) bool
+// ID is used to break the ties between repo activities
+func (r groupedRepoActivityRanking) ID(i int) string {
+	return r.activities[i].ID
 }
 
-// Less defines the way to determine lesser element (Sort interface)
-func (r userHeap) Less(i, j int) bool {
-	return r.less(r.baseUserHeap[i], r.baseUserHeap[j])
+// Group is the user of the repo activity i
+func (r groupedRepoActivityRanking) Group(i int) string {
+	return r.userIDs[i]
 }
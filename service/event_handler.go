@@ -45,14 +45,41 @@ func BuildEvents(dataStore *db.DataStore) map[string]*Event {
 		eventsMap[event.ID] = &event
 	}
 
-	for _, commitRec := range dataStore.CommitStore {
-		if event, ok := eventsMap[commitRec.EventID]; ok {
-			if event.Commits == nil {
-				event.Commits = []entities.Commit{}
+	// every (sha, event) pair is attached, so a sha pushed to multiple repos is counted for each of them
+	for _, commitRecs := range dataStore.CommitStore {
+		for _, commitRec := range commitRecs {
+			if event, ok := eventsMap[commitRec.EventID]; ok {
+				if event.Commits == nil {
+					event.Commits = []entities.Commit{}
+				}
+				event.Commits = append(event.Commits, *commitRec)
 			}
-			event.Commits = append(event.Commits, *commitRec)
 		}
 	}
 
 	return eventsMap
 }
+
+// CountSharedCommits returns the number of commit shas which are pushed to more than one repo
+func (eh *EventHandler) CountSharedCommits() int {
+	shaRepos := make(map[string]map[string]bool)
+	for _, event := range eh.Events {
+		if event.Repo == nil {
+			continue
+		}
+		for _, commit := range event.Commits {
+			if shaRepos[commit.Sha] == nil {
+				shaRepos[commit.Sha] = map[string]bool{}
+			}
+			shaRepos[commit.Sha][event.Repo.ID] = true
+		}
+	}
+
+	count := 0
+	for _, repos := range shaRepos {
+		if len(repos) > 1 {
+			count++
+		}
+	}
+	return count
+}
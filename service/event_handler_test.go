@@ -32,6 +32,12 @@ var (
 		Message: "Message 3",
 		EventID: "332",
 	}
+	// same sha as commit1 pushed with another event, e.g. to a fork
+	commit4 = entities.Commit{
+		Sha:     "221",
+		Message: "Message 1",
+		EventID: "332",
+	}
 	event1 = entities.Event{
 		ID:      "331",
 		Type:    "PushEvent",
@@ -67,9 +73,9 @@ func TestBuildEvents(t *testing.T) {
 					actor1.ID: &actor1,
 					actor2.ID: &actor2,
 				},
-				CommitStore: map[string]*entities.Commit{
-					commit1.Sha: &commit1,
-					commit2.Sha: &commit2,
+				CommitStore: map[string][]*entities.Commit{
+					commit1.Sha: {&commit1},
+					commit2.Sha: {&commit2},
 				},
 				EventStore: map[string]*entities.Event{
 					event1.ID: &event1,
@@ -81,8 +87,8 @@ func TestBuildEvents(t *testing.T) {
 				},
 			},
 			exp: map[string]*Event{
-				event1.ID: {event1.ID, event1.Type, &actor1, &repo1, []entities.Commit{commit1}},
-				event2.ID: {event2.ID, event2.Type, &actor2, &repo2, []entities.Commit{commit2}},
+				event1.ID: {ID: event1.ID, Type: event1.Type, Actor: &actor1, Repo: &repo1, Commits: []entities.Commit{commit1}},
+				event2.ID: {ID: event2.ID, Type: event2.Type, Actor: &actor2, Repo: &repo2, Commits: []entities.Commit{commit2}},
 			},
 		},
 		{
@@ -92,10 +98,10 @@ func TestBuildEvents(t *testing.T) {
 					actor1.ID: &actor1,
 					actor2.ID: &actor2,
 				},
-				CommitStore: map[string]*entities.Commit{
-					commit1.Sha: &commit1,
-					commit2.Sha: &commit2,
-					commit3.Sha: &commit3,
+				CommitStore: map[string][]*entities.Commit{
+					commit1.Sha: {&commit1},
+					commit2.Sha: {&commit2},
+					commit3.Sha: {&commit3},
 				},
 				EventStore: map[string]*entities.Event{
 					event1.ID: &event1,
@@ -107,8 +113,33 @@ func TestBuildEvents(t *testing.T) {
 				},
 			},
 			exp: map[string]*Event{
-				event1.ID: {event1.ID, event1.Type, &actor1, &repo1, []entities.Commit{commit1}},
-				event2.ID: {event2.ID, event2.Type, &actor2, &repo2, []entities.Commit{commit2, commit3}},
+				event1.ID: {ID: event1.ID, Type: event1.Type, Actor: &actor1, Repo: &repo1, Commits: []entities.Commit{commit1}},
+				event2.ID: {ID: event2.ID, Type: event2.Type, Actor: &actor2, Repo: &repo2, Commits: []entities.Commit{commit2, commit3}},
+			},
+		},
+		{
+			name: "same commit pushed with multiple events",
+			ds: db.DataStore{
+				ActorStore: map[string]*entities.Actor{
+					actor1.ID: &actor1,
+					actor2.ID: &actor2,
+				},
+				CommitStore: map[string][]*entities.Commit{
+					commit1.Sha: {&commit1, &commit4},
+					commit2.Sha: {&commit2},
+				},
+				EventStore: map[string]*entities.Event{
+					event1.ID: &event1,
+					event2.ID: &event2,
+				},
+				RepoStore: map[string]*entities.Repo{
+					repo1.ID: &repo1,
+					repo2.ID: &repo2,
+				},
+			},
+			exp: map[string]*Event{
+				event1.ID: {ID: event1.ID, Type: event1.Type, Actor: &actor1, Repo: &repo1, Commits: []entities.Commit{commit1}},
+				event2.ID: {ID: event2.ID, Type: event2.Type, Actor: &actor2, Repo: &repo2, Commits: []entities.Commit{commit2, commit4}},
 			},
 		},
 	}
@@ -134,3 +165,42 @@ func TestBuildEvents(t *testing.T) {
 		})
 	}
 }
+
+func TestCountSharedCommits(t *testing.T) {
+	tests := []struct {
+		name   string
+		events map[string]*Event
+		exp    int
+	}{
+		{
+			name: "no shared commits",
+			events: map[string]*Event{
+				event1.ID: {ID: event1.ID, Type: event1.Type, Actor: &actor1, Repo: &repo1, Commits: []entities.Commit{commit1}},
+				event2.ID: {ID: event2.ID, Type: event2.Type, Actor: &actor2, Repo: &repo2, Commits: []entities.Commit{commit2, commit3}},
+			},
+			exp: 0,
+		},
+		{
+			name: "commit pushed to multiple repos",
+			events: map[string]*Event{
+				event1.ID: {ID: event1.ID, Type: event1.Type, Actor: &actor1, Repo: &repo1, Commits: []entities.Commit{commit1}},
+				event2.ID: {ID: event2.ID, Type: event2.Type, Actor: &actor2, Repo: &repo2, Commits: []entities.Commit{commit2, commit4}},
+			},
+			exp: 1,
+		},
+		{
+			name: "commit pushed multiple times to the same repo",
+			events: map[string]*Event{
+				event1.ID: {ID: event1.ID, Type: event1.Type, Actor: &actor1, Repo: &repo1, Commits: []entities.Commit{commit1}},
+				event2.ID: {ID: event2.ID, Type: event2.Type, Actor: &actor2, Repo: &repo1, Commits: []entities.Commit{commit4}},
+			},
+			exp: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			eventHandler := EventHandler{DataStore: nil, Events: tt.events}
+			assert.Equal(t, tt.exp, eventHandler.CountSharedCommits())
+		})
+	}
+}
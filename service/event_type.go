@@ -0,0 +1,31 @@
+package service
+
+// EventTypes are the types of the public GitHub events
+var EventTypes = []string{
+	"CommitCommentEvent",
+	"CreateEvent",
+	"DeleteEvent",
+	"ForkEvent",
+	"GollumEvent",
+	"IssueCommentEvent",
+	"IssuesEvent",
+	"MemberEvent",
+	"PublicEvent",
+	"PullRequestEvent",
+	"PullRequestReviewEvent",
+	"PullRequestReviewCommentEvent",
+	"PushEvent",
+	"ReleaseEvent",
+	"SponsorshipEvent",
+	"WatchEvent",
+}
+
+// IsEventType reports whether the name is a type of GitHub event
+func IsEventType(name string) bool {
+	for _, eventType := range EventTypes {
+		if eventType == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,25 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsEventType(t *testing.T) {
+	tests := []struct {
+		name string
+		exp  bool
+	}{
+		{name: "PushEvent", exp: true},
+		{name: "WatchEvent", exp: true},
+		{name: "Push", exp: false},
+		{name: "pushevent", exp: false},
+		{name: "FooEvent", exp: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.exp, IsEventType(tt.name))
+		})
+	}
+}
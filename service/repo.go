@@ -0,0 +1,17 @@
+package service
+
+import "strings"
+
+// RepoOwner returns the owner (user or organization) from the repo name
+// e.g. DSC-RPI for DSC-RPI/dsc-portal
+func RepoOwner(name string) string {
+	if i := strings.Index(name, "/"); i >= 0 {
+		return name[:i]
+	}
+	return name
+}
+
+// IsOwnRepo reports whether the repo is owned by the user, GitHub usernames are case insensitive
+func IsOwnRepo(username, repoName string) bool {
+	return strings.EqualFold(username, RepoOwner(repoName))
+}
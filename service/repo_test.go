@@ -0,0 +1,75 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRepoOwner(t *testing.T) {
+	tests := []struct {
+		name string
+		repo string
+		exp  string
+	}{
+		{
+			name: "owner & repo name",
+			repo: "DSC-RPI/dsc-portal",
+			exp:  "DSC-RPI",
+		},
+		{
+			name: "repo name containing slash",
+			repo: "ArturoCamacho0/Project/Responsive",
+			exp:  "ArturoCamacho0",
+		},
+		{
+			name: "name without owner",
+			repo: "dsc-portal",
+			exp:  "dsc-portal",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.exp, RepoOwner(tt.repo))
+		})
+	}
+}
+
+func TestIsOwnRepo(t *testing.T) {
+	tests := []struct {
+		name     string
+		username string
+		repo     string
+		exp      bool
+	}{
+		{
+			name:     "own repo",
+			username: "ameykpatil",
+			repo:     "ameykpatil/github-data-analyzer",
+			exp:      true,
+		},
+		{
+			name:     "own repo with different case",
+			username: "AmeykPatil",
+			repo:     "ameykpatil/github-data-analyzer",
+			exp:      true,
+		},
+		{
+			name:     "external repo",
+			username: "ameykpatil",
+			repo:     "DSC-RPI/dsc-portal",
+			exp:      false,
+		},
+		{
+			name:     "username prefix of owner",
+			username: "DSC",
+			repo:     "DSC-RPI/dsc-portal",
+			exp:      false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.exp, IsOwnRepo(tt.username, tt.repo))
+		})
+	}
+}